@@ -0,0 +1,119 @@
+package soduku
+
+import (
+	"math/bits"
+)
+
+// digitSet is a set of numbers between 1 and maxSize, bit n is set when n is in the set
+type digitSet uint32
+
+// digitsTo returns the set of every number from 1 to n
+func digitsTo(n int) digitSet {
+	return digitSet(1)<<uint(n+1) - 2
+}
+
+// has returns whether num is in the set
+func (d digitSet) has(num int) bool {
+	return d&(1<<uint(num)) != 0
+}
+
+// count returns how many numbers are in the set
+func (d digitSet) count() int {
+	return bits.OnesCount32(uint32(d))
+}
+
+// numbers returns the numbers in the set in ascending order
+func (d digitSet) numbers() []int {
+	nums := make([]int, 0, d.count())
+	for d != 0 {
+		num := bits.TrailingZeros32(uint32(d))
+		nums = append(nums, num)
+		d &^= 1 << uint(num)
+	}
+	return nums
+}
+
+// board wraps a grid with bitmasks of the numbers used in each house of its geometry. The
+// masks are kept up to date as numbers are placed and removed, so finding the possible
+// numbers for a square is a few bit operations rather than a scan of the grid
+type board struct {
+	grid [][]int
+	geo  *geometry
+	// used holds the numbers placed in each house, indexed like geo.houses
+	used []digitSet
+	// cageUsed holds the numbers placed in each cage, indexed like geo.cages
+	cageUsed []digitSet
+}
+
+// newBoard builds the masks for the grid. The board works on the grid in place, so numbers
+// placed on the board are visible in the grid
+func newBoard(geo *geometry, grid [][]int) *board {
+	b := &board{
+		grid:     grid,
+		geo:      geo,
+		used:     make([]digitSet, len(geo.houses)),
+		cageUsed: make([]digitSet, len(geo.cages)),
+	}
+	for row := 0; row < geo.size; row++ {
+		for col := 0; col < geo.size; col++ {
+			num := grid[row][col]
+			if num < 1 || num > geo.size {
+				continue
+			}
+			b.mark(row, col, 1<<uint(num))
+		}
+	}
+	return b
+}
+
+// mark adds the numbers to the masks covering the position
+func (b *board) mark(row, col int, d digitSet) {
+	for _, h := range b.geo.housesAt[row][col] {
+		b.used[h] |= d
+	}
+	if c := b.geo.cageOf[row][col]; c >= 0 {
+		b.cageUsed[c] |= d
+	}
+}
+
+// place puts num into an empty square
+func (b *board) place(row, col, num int) {
+	b.grid[row][col] = num
+	b.mark(row, col, 1<<uint(num))
+}
+
+// taken returns the numbers already placed in a house or cage holding the position
+func (b *board) taken(row, col int) digitSet {
+	used := digitSet(0)
+	for _, h := range b.geo.housesAt[row][col] {
+		used |= b.used[h]
+	}
+	if c := b.geo.cageOf[row][col]; c >= 0 {
+		used |= b.cageUsed[c]
+	}
+	return used
+}
+
+// peerHolding returns a square in a house holding the position that holds num, looking in
+// its row, then column, then region, then its cage
+func (b *board) peerHolding(pos position, num int) (Cell, bool) {
+	d := digitSet(1) << uint(num)
+	for _, i := range b.geo.housesAt[pos.rowNumber][pos.colNumber] {
+		if b.used[i]&d == 0 {
+			continue
+		}
+		for _, p := range b.geo.houses[i].positions {
+			if b.grid[p.rowNumber][p.colNumber] == num {
+				return Cell{Row: p.rowNumber, Col: p.colNumber}, true
+			}
+		}
+	}
+	if c := b.geo.cageOf[pos.rowNumber][pos.colNumber]; c >= 0 && b.cageUsed[c]&d != 0 {
+		for _, p := range b.geo.cages[c].positions {
+			if b.grid[p.rowNumber][p.colNumber] == num {
+				return Cell{Row: p.rowNumber, Col: p.colNumber}, true
+			}
+		}
+	}
+	return Cell{}, false
+}
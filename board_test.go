@@ -0,0 +1,76 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigitSet(t *testing.T) {
+	tt := []struct {
+		description   string
+		input         digitSet
+		expectNumbers []int
+	}{
+		{
+			description:   "empty",
+			input:         0,
+			expectNumbers: []int{},
+		},
+		{
+			description:   "all digits",
+			input:         digitsTo(9),
+			expectNumbers: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			description:   "some digits",
+			input:         1<<2 | 1<<5 | 1<<9,
+			expectNumbers: []int{2, 5, 9},
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			assert.Equal(t, td.expectNumbers, td.input.numbers())
+			assert.Equal(t, len(td.expectNumbers), td.input.count())
+			for _, num := range td.expectNumbers {
+				assert.True(t, td.input.has(num))
+			}
+		})
+	}
+}
+
+func TestBoard(t *testing.T) {
+	grid := [][]int{
+		[]int{0, 0, 0, 4, 5, 6, 7, 8, 9},
+		[]int{0, 0, 0, 7, 8, 9, 1, 2, 3},
+		[]int{0, 0, 0, 1, 2, 3, 4, 5, 6},
+		[]int{0, 0, 0, 5, 6, 7, 8, 9, 1},
+		[]int{0, 0, 0, 8, 9, 1, 2, 3, 4},
+		[]int{0, 0, 0, 2, 3, 4, 5, 6, 7},
+		[]int{0, 0, 0, 6, 7, 8, 9, 1, 2},
+		[]int{0, 0, 0, 9, 1, 2, 3, 4, 5},
+		[]int{0, 0, 0, 3, 4, 5, 6, 7, 8},
+	}
+	b := newBoard(newGeometry(3, 3), grid)
+	possible := func(row, col int) []int {
+		return (b.geo.digits &^ b.taken(row, col)).numbers()
+	}
+	assert.Equal(t, []int{1, 2, 3}, possible(0, 0))
+
+	b.place(0, 0, 1)
+	assert.Equal(t, 1, grid[0][0])
+	assert.Equal(t, []int{2, 3}, possible(0, 1))
+	assert.Equal(t, []int{4, 5, 6}, possible(1, 0))
+	assert.Equal(t, []int{7, 8, 9}, possible(2, 2))
+	assert.Equal(t, []int{2, 3, 4}, possible(3, 0))
+}
+
+func TestRegionNumber(t *testing.T) {
+	geo := newGeometry(3, 3)
+	for regNumber, reg := range geo.regions {
+		for _, pos := range reg {
+			assert.Equal(t, regNumber, geo.regionNumber(pos.rowNumber, pos.colNumber))
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package soduku
+
+import (
+	"fmt"
+)
+
+// Candidate is a number that may still go in a square
+type Candidate struct {
+	Cell
+	Digit int `json:"digit"`
+}
+
+func (c Candidate) String() string {
+	return fmt.Sprintf("%s#%d", c.Cell, c.Digit)
+}
+
+// CandidateBoard is the logical solver's view of the grid, it is what a Strategy looks at. On
+// top of the numbers in the grid it keeps the numbers still possible in every empty square, so
+// numbers removed by a strategy stay removed for the rest of the solve
+type CandidateBoard struct {
+	*board
+	cands [][]digitSet
+}
+
+// newCandidateBoard starts every empty square with the numbers not already in its row, column,
+// region or cage. Cage sums are left to CageSum, so every candidate removed is in the solve
+// log. It works on the grid in place, like newBoard
+func newCandidateBoard(geo *geometry, grid [][]int) *CandidateBoard {
+	cb := &CandidateBoard{board: newBoard(geo, grid), cands: make([][]digitSet, geo.size)}
+	for row := 0; row < geo.size; row++ {
+		cb.cands[row] = make([]digitSet, geo.size)
+		for col := 0; col < geo.size; col++ {
+			if grid[row][col] == 0 {
+				cb.cands[row][col] = cb.geo.digits &^ cb.taken(row, col)
+			}
+		}
+	}
+	return cb
+}
+
+// NewCandidateBoard returns the candidate board for a grid, with every empty square allowing
+// the numbers not already in its row, column or box. The grid is copied, so strategies can be
+// tried out on it without changing the grid. Options give the layout of the grid, as for
+// SolveGrid
+func NewCandidateBoard(grid [][]int, opts ...Option) (*CandidateBoard, error) {
+	geo, err := newOptions(opts).geometry(grid)
+	if err != nil {
+		return nil, err
+	}
+	return newCandidateBoard(geo, copyGrid(grid)), nil
+}
+
+// Get returns the number in the square, or 0 if it is empty
+func (cb *CandidateBoard) Get(c Cell) int {
+	return cb.grid[c.Row][c.Col]
+}
+
+// Candidates returns the numbers still possible in the square in order, it is empty for a
+// filled square
+func (cb *CandidateBoard) Candidates(c Cell) []int {
+	return cb.cands[c.Row][c.Col].numbers()
+}
+
+// Units returns every row, column and box of the grid, followed by the diagonals of a
+// Sudoku-X grid and any extra units
+func (cb *CandidateBoard) Units() []Unit {
+	units := make([]Unit, 0, len(cb.geo.houses))
+	for _, h := range cb.geo.houses {
+		units = append(units, h.Unit)
+	}
+	return units
+}
+
+// Cells returns the squares of the unit in reading order
+func (cb *CandidateBoard) Cells(u Unit) []Cell {
+	for _, h := range cb.geo.houses {
+		if h.Unit == u {
+			return cellsOf(h.positions)
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of the board that can be changed without affecting this one
+func (cb *CandidateBoard) clone() *CandidateBoard {
+	b := &board{
+		grid:     copyGrid(cb.grid),
+		geo:      cb.geo,
+		used:     append([]digitSet{}, cb.used...),
+		cageUsed: append([]digitSet{}, cb.cageUsed...),
+	}
+	c := &CandidateBoard{board: b, cands: make([][]digitSet, len(cb.cands))}
+	for row := range cb.cands {
+		c.cands[row] = append([]digitSet{}, cb.cands[row]...)
+	}
+	return c
+}
+
+// candidates returns the numbers still possible at the position, empty for a filled square
+func (cb *CandidateBoard) candidates(pos position) digitSet {
+	return cb.cands[pos.rowNumber][pos.colNumber]
+}
+
+// mostConstrained returns the empty position with the fewest candidates, and false if there
+// are no empty positions. Unlike the board's, it counts the candidates that steps removed
+func (cb *CandidateBoard) mostConstrained() (position, digitSet, bool) {
+	best, bestCands, found := position{}, digitSet(0), false
+	for _, pos := range getEmptySquares(cb.grid) {
+		if c := cb.candidates(pos); !found || c.count() < bestCands.count() {
+			best, bestCands, found = pos, c, true
+		}
+	}
+	return best, bestCands, found
+}
+
+// place puts num into the square and removes it from the candidates of every peer, including
+// the rest of its cage
+func (cb *CandidateBoard) place(row, col, num int) {
+	cb.board.place(row, col, num)
+	cb.cands[row][col] = 0
+	d := ^(digitSet(1) << uint(num))
+	for _, h := range cb.geo.housesOf(position{rowNumber: row, colNumber: col}) {
+		for _, pos := range h.positions {
+			cb.cands[pos.rowNumber][pos.colNumber] &= d
+		}
+	}
+	if c := cb.geo.cageOf[row][col]; c >= 0 {
+		for _, pos := range cb.geo.cages[c].positions {
+			cb.cands[pos.rowNumber][pos.colNumber] &= d
+		}
+	}
+}
+
+// progress returns whether applying the step would change the board, either by placing a
+// candidate in its square or by removing a candidate that is still possible. A filled square
+// has no candidates
+func (cb *CandidateBoard) progress(s *Step) bool {
+	if s.IsPlacement() {
+		return cb.geo.onGrid(s.Cell) && s.Digit >= 1 && s.Digit <= cb.geo.size &&
+			cb.cands[s.Cell.Row][s.Cell.Col].has(s.Digit)
+	}
+	for _, e := range s.Eliminations {
+		if cb.geo.onGrid(e.Cell) && cb.cands[e.Row][e.Col].has(e.Digit) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply carries out a step, placing its number and removing its eliminations
+func (cb *CandidateBoard) apply(s *Step) {
+	if s.Digit != 0 {
+		cb.place(s.Cell.Row, s.Cell.Col, s.Digit)
+	}
+	for _, e := range s.Eliminations {
+		if cb.geo.onGrid(e.Cell) {
+			cb.cands[e.Row][e.Col] &^= 1 << uint(e.Digit)
+		}
+	}
+}
@@ -0,0 +1,416 @@
+package soduku
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// SimpleColoring colours the squares of one number joined by houses where it can only go in
+	// two squares. One colour holds the number, so a square that sees both colours cannot, and
+	// a colour that sees itself is false everywhere
+	SimpleColoring Technique = "simple coloring"
+	// XChain is an alternating chain of one number. One of its ends holds the number, so it is
+	// removed from every square that sees both ends
+	XChain Technique = "x-chain"
+	// XYChain is an alternating chain through squares that each allow two numbers, starting
+	// and ending on the same number
+	XYChain Technique = "xy-chain"
+	// AIC is an alternating inference chain mixing links between squares and links inside a
+	// square. One of its ends is true, which removes any candidate that would break both
+	AIC Technique = "alternating inference chain"
+)
+
+// LinkType is the kind of inference joining two candidates of a chain
+type LinkType int
+
+const (
+	// LinkStrong means at least one of the candidates is true
+	LinkStrong LinkType = iota
+	// LinkWeak means at most one of the candidates is true
+	LinkWeak
+)
+
+var linkTypeNames = map[LinkType]string{
+	LinkStrong: "strong",
+	LinkWeak:   "weak",
+}
+
+func (l LinkType) String() string {
+	if name, ok := linkTypeNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("LinkType(%d)", int(l))
+}
+
+// MarshalText writes the link type by name, so it reads well in JSON
+func (l LinkType) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// Chain is an ordered list of candidates, Links[i] joins Nodes[i] to Nodes[i+1]
+type Chain struct {
+	Nodes []Candidate `json:"nodes"`
+	Links []LinkType  `json:"links"`
+}
+
+// String writes the chain with = for a strong link and - for a weak link
+func (c Chain) String() string {
+	var sb strings.Builder
+	for i, n := range c.Nodes {
+		if i > 0 {
+			link := " - "
+			if c.Links[i-1] == LinkStrong {
+				link = " = "
+			}
+			sb.WriteString(link)
+		}
+		sb.WriteString(n.String())
+	}
+	return sb.String()
+}
+
+// chainNode is a candidate of the board, numbered by square in reading order then number. The
+// numbering leaves room for the largest grid, so it does not depend on the size of the board
+type chainNode int
+
+func nodeOf(pos position, num int) chainNode {
+	return chainNode((pos.rowNumber*maxSize+pos.colNumber)*maxSize + num - 1)
+}
+
+func (n chainNode) pos() position {
+	cell := int(n) / maxSize
+	return position{rowNumber: cell / maxSize, colNumber: cell % maxSize}
+}
+
+func (n chainNode) num() int {
+	return int(n)%maxSize + 1
+}
+
+func (n chainNode) candidate() Candidate {
+	pos := n.pos()
+	return Candidate{Cell: Cell{Row: pos.rowNumber, Col: pos.colNumber}, Digit: n.num()}
+}
+
+// chainNodes returns every candidate on the board in order
+func chainNodes(b *CandidateBoard) []chainNode {
+	nodes := []chainNode{}
+	for _, pos := range getEmptySquares(b.grid) {
+		for _, num := range b.candidates(pos).numbers() {
+			nodes = append(nodes, nodeOf(pos, num))
+		}
+	}
+	return nodes
+}
+
+// links returns the candidates joined to n by a strong link, or when strong is false by a
+// weak link. Every strong link is also a weak link
+func links(b *CandidateBoard, n chainNode, strong bool) []chainNode {
+	pos, num := n.pos(), n.num()
+	linked := []chainNode{}
+	add := func(other chainNode) {
+		for _, l := range linked {
+			if l == other {
+				return
+			}
+		}
+		linked = append(linked, other)
+	}
+
+	cands := b.candidates(pos)
+	if !strong || cands.count() == 2 {
+		for _, other := range cands.numbers() {
+			if other != num {
+				add(nodeOf(pos, other))
+			}
+		}
+	}
+	for _, h := range b.geo.housesOf(pos) {
+		poss := positionsAllowing(b, h, num)
+		if strong && len(poss) != 2 {
+			continue
+		}
+		for _, p := range poss {
+			if p != pos {
+				add(nodeOf(p, num))
+			}
+		}
+	}
+	return linked
+}
+
+// linkFilter decides whether a link may be used by a kind of chain
+type linkFilter func(b *CandidateBoard, from, to chainNode, strong bool) bool
+
+// xChainLinks only follows links between squares for the same number
+func xChainLinks(b *CandidateBoard, from, to chainNode, strong bool) bool {
+	return from.num() == to.num()
+}
+
+// xyChainLinks follows the link inside a square with two numbers as the strong link, and a
+// number shared with the next such square as the weak link
+func xyChainLinks(b *CandidateBoard, from, to chainNode, strong bool) bool {
+	if b.candidates(from.pos()).count() != 2 || b.candidates(to.pos()).count() != 2 {
+		return false
+	}
+	return strong == (from.pos() == to.pos())
+}
+
+// aicLinks follows every link
+func aicLinks(b *CandidateBoard, from, to chainNode, strong bool) bool {
+	return true
+}
+
+// findXChain returns the shortest x-chain from the first candidate that has one
+func findXChain(b *CandidateBoard) *Step {
+	return findChain(b, XChain, xChainLinks)
+}
+
+// findXYChain returns the shortest xy-chain from the first candidate that has one
+func findXYChain(b *CandidateBoard) *Step {
+	return findChain(b, XYChain, xyChainLinks)
+}
+
+// findAIC returns the shortest alternating inference chain from the first candidate that has
+// one
+func findAIC(b *CandidateBoard) *Step {
+	return findChain(b, AIC, aicLinks)
+}
+
+// findChain searches breadth first from each candidate for a chain that starts and ends with a
+// strong link, using only the links the filter allows. The first chain that removes a
+// candidate is returned, so chains are as short as possible for their start
+func findChain(b *CandidateBoard, t Technique, allowed linkFilter) *Step {
+	for _, start := range chainNodes(b) {
+		// A state is a node and whether it was reached by a strong link. The start counts as
+		// reached by a weak link, so the chain leaves it by a strong one
+		startState := int(start) * 2
+		parent := map[int]int{startState: -1}
+		queue := []int{startState}
+		for len(queue) > 0 {
+			state := queue[0]
+			queue = queue[1:]
+			n, viaStrong := chainNode(state/2), state%2 == 1
+
+			if viaStrong {
+				chain := chainTo(parent, state)
+				if chain != nil {
+					if elims := chainEliminations(b, start, n); len(elims) > 0 {
+						return &Step{Technique: t, Eliminations: elims, Chain: chain}
+					}
+				}
+			}
+
+			strong := !viaStrong
+			for _, next := range links(b, n, strong) {
+				if !allowed(b, n, next, strong) {
+					continue
+				}
+				nextState := int(next) * 2
+				if strong {
+					nextState++
+				}
+				if _, ok := parent[nextState]; ok {
+					continue
+				}
+				parent[nextState] = state
+				queue = append(queue, nextState)
+			}
+		}
+	}
+	return nil
+}
+
+// chainTo follows the parents back from state to build the chain, or returns nil if the
+// chain passes through a candidate twice
+func chainTo(parent map[int]int, state int) *Chain {
+	states := []int{}
+	for s := state; s != -1; s = parent[s] {
+		states = append([]int{s}, states...)
+	}
+
+	seen := map[chainNode]bool{}
+	chain := &Chain{}
+	for i, s := range states {
+		n := chainNode(s / 2)
+		if seen[n] {
+			return nil
+		}
+		seen[n] = true
+		chain.Nodes = append(chain.Nodes, n.candidate())
+		if i > 0 {
+			link := LinkWeak
+			if s%2 == 1 {
+				link = LinkStrong
+			}
+			chain.Links = append(chain.Links, link)
+		}
+	}
+	return chain
+}
+
+// chainEliminations returns the candidates removed by a chain whose ends are both strong, so
+// at least one end is true
+func chainEliminations(b *CandidateBoard, start, end chainNode) []Candidate {
+	sp, ep := start.pos(), end.pos()
+	elims := []Candidate{}
+	switch {
+	case start.num() == end.num():
+		for _, pos := range getEmptySquares(b.grid) {
+			if pos != sp && pos != ep && b.geo.sees(pos, sp) && b.geo.sees(pos, ep) {
+				elims = append(elims, candidatesOf(pos, b.candidates(pos)&(1<<uint(start.num())))...)
+			}
+		}
+	case sp == ep:
+		// The square has to hold one of the two numbers
+		keep := digitSet(1<<uint(start.num()) | 1<<uint(end.num()))
+		elims = append(elims, candidatesOf(sp, b.candidates(sp)&^keep)...)
+	case b.geo.sees(sp, ep):
+		// Either end being false makes the other true, so neither square can hold the other's
+		// number
+		elims = append(elims, candidatesOf(sp, b.candidates(sp)&(1<<uint(end.num())))...)
+		elims = append(elims, candidatesOf(ep, b.candidates(ep)&(1<<uint(start.num())))...)
+	}
+	return elims
+}
+
+// findSimpleColoring colours each group of squares joined by strong links for a number, and
+// returns the first colour wrap or colour trap that removes a candidate. The chain given is
+// the path of strong links between the two coloured squares that cause the elimination, ending
+// in a weak link back to the start for a wrap
+func findSimpleColoring(b *CandidateBoard) *Step {
+	for num := 1; num <= b.geo.size; num++ {
+		visited := map[chainNode]bool{}
+		for _, start := range chainNodes(b) {
+			if start.num() != num || visited[start] {
+				continue
+			}
+
+			// Colour the cluster breadth first, remembering the tree so paths can be drawn
+			cluster := []chainNode{start}
+			parent := map[chainNode]chainNode{start: start}
+			colored := map[chainNode]int{start: 0}
+			visited[start] = true
+			for i := 0; i < len(cluster); i++ {
+				n := cluster[i]
+				for _, next := range links(b, n, true) {
+					if next.num() != num {
+						continue
+					}
+					if _, ok := colored[next]; ok {
+						continue
+					}
+					colored[next] = 1 - colored[n]
+					visited[next] = true
+					parent[next] = n
+					cluster = append(cluster, next)
+				}
+			}
+			if len(cluster) < 3 {
+				continue
+			}
+			if step := colorWrap(b, cluster, colored, parent); step != nil {
+				return step
+			}
+			if step := colorTrap(b, cluster, colored, parent); step != nil {
+				return step
+			}
+		}
+	}
+	return nil
+}
+
+// colorWrap looks for two squares of the same colour in one house. That colour cannot hold the
+// number, so it is removed from every square of the colour
+func colorWrap(b *CandidateBoard, cluster []chainNode, colored map[chainNode]int, parent map[chainNode]chainNode) *Step {
+	for i, x := range cluster {
+		for _, y := range cluster[i+1:] {
+			if colored[x] != colored[y] || !b.geo.sees(x.pos(), y.pos()) {
+				continue
+			}
+			step := &Step{Technique: SimpleColoring, Eliminations: []Candidate{}}
+			for _, n := range cluster {
+				if colored[n] == colored[x] {
+					step.Eliminations = append(step.Eliminations, n.candidate())
+				}
+			}
+			chain := treePath(parent, x, y)
+			chain.Nodes = append(chain.Nodes, x.candidate())
+			chain.Links = append(chain.Links, LinkWeak)
+			step.Chain = chain
+			return step
+		}
+	}
+	return nil
+}
+
+// colorTrap looks for squares outside the cluster that see both colours. One colour holds the
+// number, so those squares cannot
+func colorTrap(b *CandidateBoard, cluster []chainNode, colored map[chainNode]int, parent map[chainNode]chainNode) *Step {
+	for _, target := range chainNodes(b) {
+		if target.num() != cluster[0].num() {
+			continue
+		}
+		if _, ok := colored[target]; ok {
+			continue
+		}
+		var seen [2]*chainNode
+		for i, n := range cluster {
+			if seen[colored[n]] == nil && b.geo.sees(target.pos(), n.pos()) {
+				seen[colored[n]] = &cluster[i]
+			}
+		}
+		if seen[0] == nil || seen[1] == nil {
+			continue
+		}
+
+		// Every other square that sees the same two ends goes too
+		x, y := *seen[0], *seen[1]
+		step := &Step{Technique: SimpleColoring, Eliminations: []Candidate{}, Chain: treePath(parent, x, y)}
+		for _, n := range chainNodes(b) {
+			if n.num() != target.num() || n == x || n == y {
+				continue
+			}
+			if _, ok := colored[n]; ok {
+				continue
+			}
+			if b.geo.sees(n.pos(), x.pos()) && b.geo.sees(n.pos(), y.pos()) {
+				step.Eliminations = append(step.Eliminations, n.candidate())
+			}
+		}
+		return step
+	}
+	return nil
+}
+
+// treePath returns the chain of strong links from x to y through the colouring tree
+func treePath(parent map[chainNode]chainNode, x, y chainNode) *Chain {
+	ancestors := func(n chainNode) []chainNode {
+		path := []chainNode{n}
+		for parent[n] != n {
+			n = parent[n]
+			path = append(path, n)
+		}
+		return path
+	}
+	up, down := ancestors(x), ancestors(y)
+
+	// Drop the shared part above the lowest common ancestor
+	for len(up) > 1 && len(down) > 1 && up[len(up)-2] == down[len(down)-2] {
+		up = up[:len(up)-1]
+		down = down[:len(down)-1]
+	}
+	nodes := append([]chainNode{}, up...)
+	for i := len(down) - 2; i >= 0; i-- {
+		nodes = append(nodes, down[i])
+	}
+
+	chain := &Chain{}
+	for i, n := range nodes {
+		chain.Nodes = append(chain.Nodes, n.candidate())
+		if i > 0 {
+			chain.Links = append(chain.Links, LinkStrong)
+		}
+	}
+	return chain
+}
@@ -0,0 +1,115 @@
+package soduku
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveGridChains(t *testing.T) {
+	tt := []struct {
+		description     string
+		input           string
+		expectTechnique Technique
+	}{
+		{
+			description:     "simple coloring",
+			input:           "500302009040000030007400100070100000200000006050009704010004085020000000308005000",
+			expectTechnique: SimpleColoring,
+		},
+		{
+			description:     "x-chain",
+			input:           "280006000006500000500908000010407005603090000000800000000300050900000087030002010",
+			expectTechnique: XChain,
+		},
+		{
+			description:     "xy-chain",
+			input:           "280006000006500000500908000010407005603090000000800000000300050900000087030002010",
+			expectTechnique: XYChain,
+		},
+		{
+			description:     "alternating inference chain",
+			input:           "280006000006500000500908000010407005603090000000800000000300050900000087030002010",
+			expectTechnique: AIC,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+
+			log := &SolveLog{}
+			solved, _, err := SolveGrid(g.Rows(), WithSolveLog(log))
+			require.Nil(t, err)
+
+			techniques := replaySolveLog(t, g.Rows(), solved, log)
+			assert.True(t, techniques[td.expectTechnique] > 0)
+			assert.Equal(t, 0, techniques[Search])
+
+			for _, s := range log.Steps {
+				if s.Technique != td.expectTechnique {
+					continue
+				}
+				require.NotNil(t, s.Chain)
+				require.Len(t, s.Chain.Links, len(s.Chain.Nodes)-1)
+				assert.Equal(t, LinkStrong, s.Chain.Links[0])
+				if s.Technique == SimpleColoring {
+					continue
+				}
+				for i, l := range s.Chain.Links {
+					assert.Equal(t, i%2 == 0, l == LinkStrong)
+				}
+				assert.Equal(t, LinkStrong, s.Chain.Links[len(s.Chain.Links)-1])
+			}
+		})
+	}
+}
+
+func TestXChain(t *testing.T) {
+	// 1 can only go in r1c1 and r1c4 of the first row, and r9c1 and r9c6 of the last. r1c1
+	// and r9c1 share a column so one of r1c4 and r9c6 holds 1, and no square seeing both can
+	b := newCandidateBoard(newGeometry(3, 3), emptyTestGrid())
+	keep := map[int][]int{0: {0, 3}, 8: {0, 5}}
+	for row, cols := range keep {
+		for col := 0; col <= 8; col++ {
+			if col != cols[0] && col != cols[1] {
+				b.apply(&Step{Eliminations: []Candidate{{Cell: Cell{Row: row, Col: col}, Digit: 1}}})
+			}
+		}
+	}
+
+	step := findXChain(b)
+	require.NotNil(t, step)
+	assert.Equal(t, XChain, step.Technique)
+	assert.Equal(t, "r1c4#1 = r1c1#1 - r9c1#1 = r9c6#1", step.Chain.String())
+	assert.Equal(t, []Candidate{
+		{Cell: Cell{Row: 1, Col: 5}, Digit: 1},
+		{Cell: Cell{Row: 2, Col: 5}, Digit: 1},
+		{Cell: Cell{Row: 6, Col: 3}, Digit: 1},
+		{Cell: Cell{Row: 7, Col: 3}, Digit: 1},
+	}, step.Eliminations)
+}
+
+func TestChainJSON(t *testing.T) {
+	chain := Chain{
+		Nodes: []Candidate{
+			{Cell: Cell{Row: 0, Col: 1}, Digit: 1},
+			{Cell: Cell{Row: 0, Col: 0}, Digit: 1},
+			{Cell: Cell{Row: 8, Col: 0}, Digit: 1},
+		},
+		Links: []LinkType{LinkStrong, LinkWeak},
+	}
+	out, err := json.Marshal(chain)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"nodes": [
+			{"row": 0, "col": 1, "digit": 1},
+			{"row": 0, "col": 0, "digit": 1},
+			{"row": 8, "col": 0, "digit": 1}
+		],
+		"links": ["strong", "weak"]
+	}`, string(out))
+}
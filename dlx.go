@@ -0,0 +1,246 @@
+package soduku
+
+import (
+	"context"
+)
+
+// The grid is modelled as an exact cover matrix. Every row of the matrix is a candidate
+// placement of a number in a square, and every column is a constraint that has to be
+// satisfied exactly once:
+//
+//	cell constraints       each square holds one number
+//	house constraints      each row, column and region holds each number once
+//
+// For a grid of size n the cell constraints come first, n*n of them in reading order, then n
+// constraints for each house of the geometry in turn. The matrix is solved with Knuth's
+// Algorithm X, using Dancing Links to cover and uncover columns. The nodes are stored in
+// slices and linked by index rather than by pointer. Killer cages are not part of the matrix,
+// see solveDLX.
+
+// dlx is a sparse exact cover matrix. Node 0 is the root, the nodes after it up to the number
+// of constraints are the column headers and every node after that is a one in the matrix
+type dlx struct {
+	left, right, up, down []int
+	// col is the column header of each node
+	col []int
+	// placement is the matrix row that each node belongs to
+	placement []dlxPlacement
+	// size is the number of nodes remaining in each column
+	size []int
+	// chosen holds the matrix rows picked on the way to the current partial solution
+	chosen []dlxPlacement
+}
+
+// dlxPlacement is a matrix row, a number in a given position
+type dlxPlacement struct {
+	pos position
+	num int
+}
+
+// newDLX builds the exact cover matrix for the grid. Squares that are already filled only get
+// the matrix row for their number, so every solution found agrees with the grid
+func newDLX(geo *geometry, grid [][]int) *dlx {
+	n := geo.size
+	houseConstraints := n * n
+	totalConstraints := houseConstraints + len(geo.houses)*n
+
+	// Every square has at most n matrix rows, each with a one in 4 columns for a grid with no
+	// extra houses
+	nodes := totalConstraints + 1 + n*n*n*4
+	d := &dlx{
+		left:      make([]int, 0, nodes),
+		right:     make([]int, 0, nodes),
+		up:        make([]int, 0, nodes),
+		down:      make([]int, 0, nodes),
+		col:       make([]int, 0, nodes),
+		placement: make([]dlxPlacement, 0, nodes),
+		size:      make([]int, 0, totalConstraints+1),
+	}
+	for i := 0; i <= totalConstraints; i++ {
+		d.left = append(d.left, i-1)
+		d.right = append(d.right, i+1)
+		d.up = append(d.up, i)
+		d.down = append(d.down, i)
+		d.col = append(d.col, i)
+		d.placement = append(d.placement, dlxPlacement{})
+		d.size = append(d.size, 0)
+	}
+	d.left[0] = totalConstraints
+	d.right[totalConstraints] = 0
+
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			for num := 1; num <= n; num++ {
+				if grid[row][col] != 0 && grid[row][col] != num {
+					continue
+				}
+				constraints := []int{row*n + col}
+				for _, h := range geo.housesAt[row][col] {
+					constraints = append(constraints, houseConstraints+h*n+num-1)
+				}
+				d.addPlacement(dlxPlacement{pos: position{rowNumber: row, colNumber: col}, num: num}, constraints)
+			}
+		}
+	}
+	return d
+}
+
+// addPlacement appends a matrix row with a one in each of the given constraint columns
+func (d *dlx) addPlacement(p dlxPlacement, constraints []int) {
+	first := len(d.col)
+	for i, c := range constraints {
+		header := c + 1
+		node := len(d.col)
+		d.col = append(d.col, header)
+		d.placement = append(d.placement, p)
+
+		// Insert the node at the bottom of its column
+		d.up = append(d.up, d.up[header])
+		d.down = append(d.down, header)
+		d.down[d.up[header]] = node
+		d.up[header] = node
+		d.size[header]++
+
+		// Link the node into the matrix row
+		if i == 0 {
+			d.left = append(d.left, node)
+			d.right = append(d.right, node)
+			continue
+		}
+		d.left = append(d.left, node-1)
+		d.right = append(d.right, first)
+		d.right[node-1] = node
+		d.left[first] = node
+	}
+}
+
+// cover removes a column from the header list, and every matrix row with a one in that column
+// from the other columns
+func (d *dlx) cover(c int) {
+	d.right[d.left[c]] = d.right[c]
+	d.left[d.right[c]] = d.left[c]
+	for i := d.down[c]; i != c; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.down[d.up[j]] = d.down[j]
+			d.up[d.down[j]] = d.up[j]
+			d.size[d.col[j]]--
+		}
+	}
+}
+
+// uncover reverses cover, it must be called in the opposite order to cover
+func (d *dlx) uncover(c int) {
+	for i := d.up[c]; i != c; i = d.up[i] {
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.size[d.col[j]]++
+			d.down[d.up[j]] = j
+			d.up[d.down[j]] = j
+		}
+	}
+	d.right[d.left[c]] = c
+	d.left[d.right[c]] = c
+}
+
+// search runs Algorithm X, calling found with the placements of every solution. Returning
+// true from found stops the search, and search then returns true
+func (d *dlx) search(found func([]dlxPlacement) bool) bool {
+	if d.right[0] == 0 {
+		return found(d.chosen)
+	}
+
+	// Choose the column with the fewest ones, this keeps the branching factor low
+	c := d.right[0]
+	for j := d.right[c]; j != 0; j = d.right[j] {
+		if d.size[j] < d.size[c] {
+			c = j
+		}
+	}
+	if d.size[c] == 0 {
+		return false
+	}
+
+	d.cover(c)
+	for r := d.down[c]; r != c; r = d.down[r] {
+		d.chosen = append(d.chosen, d.placement[r])
+		for j := d.right[r]; j != r; j = d.right[j] {
+			d.cover(d.col[j])
+		}
+
+		stop := d.search(found)
+
+		for j := d.left[r]; j != r; j = d.left[j] {
+			d.uncover(d.col[j])
+		}
+		d.chosen = d.chosen[:len(d.chosen)-1]
+		if stop {
+			d.uncover(c)
+			return true
+		}
+	}
+	d.uncover(c)
+	return false
+}
+
+// solveDLX returns a completed copy of the grid, or ErrNoSolution if the grid cannot be
+// completed. The grid must fit the geometry. Cage sums cannot be written as exact cover, so a
+// killer grid is searched with bruteForceGuess instead
+func solveDLX(geo *geometry, grid [][]int) ([][]int, error) {
+	if len(geo.cages) > 0 {
+		solved, _, err := bruteForceGuess(geo, grid)
+		return solved, err
+	}
+	var solution [][]int
+	newDLX(geo, grid).search(func(ps []dlxPlacement) bool {
+		solution = placementsToGrid(geo.size, ps)
+		return true
+	})
+	if solution == nil {
+		return nil, ErrNoSolution
+	}
+	return solution, nil
+}
+
+// placementsToGrid builds a size x size grid from a full set of placements
+func placementsToGrid(size int, ps []dlxPlacement) [][]int {
+	grid := make([][]int, size)
+	for i := range grid {
+		grid[i] = make([]int, size)
+	}
+	for _, p := range ps {
+		grid[p.pos.rowNumber][p.pos.colNumber] = p.num
+	}
+	return grid
+}
+
+// CountSolutions returns the number of ways the grid can be completed. Counting stops once
+// limit solutions have been found, a limit of zero or less counts every solution. Options
+// give the layout of the grid, as for SolveGrid
+func CountSolutions(grid [][]int, limit int, opts ...Option) (int, error) {
+	geo, err := newOptions(opts).geometry(grid)
+	if err != nil {
+		return 0, err
+	}
+	return countSolutions(geo, grid, limit), nil
+}
+
+// countSolutions is CountSolutions for a grid that fits the geometry. Like solveDLX it falls
+// back to the depth first search for a killer grid, which only follows the rules for the
+// squares it fills, so a grid whose givens break them has no solutions
+func countSolutions(geo *geometry, grid [][]int, limit int) int {
+	count := 0
+	if len(geo.cages) > 0 {
+		if !checkGrid(geo, grid).Valid {
+			return 0
+		}
+		search(context.Background(), geo, grid, func([][]int) bool {
+			count++
+			return limit > 0 && count >= limit
+		})
+		return count
+	}
+	newDLX(geo, grid).search(func([]dlxPlacement) bool {
+		count++
+		return limit > 0 && count >= limit
+	})
+	return count
+}
@@ -0,0 +1,236 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveDLX(t *testing.T) {
+	tt := []struct {
+		description  string
+		input        [][]int
+		expectOutput [][]int
+		expectErr    error
+	}{
+		{
+			description: "zero elements missing",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 1, 2, 3},
+				[]int{7, 8, 9, 1, 2, 3, 4, 5, 6},
+				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
+				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
+			},
+			expectOutput: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 1, 2, 3},
+				[]int{7, 8, 9, 1, 2, 3, 4, 5, 6},
+				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
+				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
+			},
+		},
+		{
+			description: "real example",
+			input: [][]int{
+				[]int{2, 0, 7, 0, 0, 6, 0, 0, 0},
+				[]int{0, 0, 0, 0, 3, 0, 2, 0, 6},
+				[]int{0, 5, 6, 0, 0, 2, 0, 4, 1},
+				[]int{1, 0, 0, 3, 0, 8, 7, 6, 0},
+				[]int{6, 0, 9, 0, 0, 0, 1, 0, 8},
+				[]int{0, 7, 4, 6, 0, 5, 0, 0, 3},
+				[]int{5, 8, 0, 7, 0, 0, 4, 1, 0},
+				[]int{9, 0, 1, 0, 5, 0, 0, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 3, 0, 5},
+			},
+			expectOutput: [][]int{
+				[]int{2, 1, 7, 8, 4, 6, 5, 3, 9},
+				[]int{4, 9, 8, 5, 3, 1, 2, 7, 6},
+				[]int{3, 5, 6, 9, 7, 2, 8, 4, 1},
+				[]int{1, 2, 5, 3, 9, 8, 7, 6, 4},
+				[]int{6, 3, 9, 4, 2, 7, 1, 5, 8},
+				[]int{8, 7, 4, 6, 1, 5, 9, 2, 3},
+				[]int{5, 8, 3, 7, 6, 9, 4, 1, 2},
+				[]int{9, 4, 1, 2, 5, 3, 6, 8, 7},
+				[]int{7, 6, 2, 1, 8, 4, 3, 9, 5},
+			},
+		},
+		{
+			description: "no number fits the last square",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 9},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			expectErr: ErrNoSolution,
+		},
+		{
+			description: "duplicate in a region",
+			input: [][]int{
+				[]int{1, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 1, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			expectErr: ErrNoSolution,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			output, err := solveDLX(newGeometry(3, 3), td.input)
+			assert.Equal(t, td.expectErr, err)
+			assert.Equal(t, td.expectOutput, output)
+		})
+	}
+}
+
+func TestSolveGridEnginesAgree(t *testing.T) {
+	tt := []struct {
+		description string
+		input       [][]int
+	}{
+		{
+			description: "real example",
+			input: [][]int{
+				[]int{3, 4, 2, 6, 9, 7, 5, 8, 1},
+				[]int{6, 1, 8, 5, 2, 4, 7, 9, 3},
+				[]int{5, 9, 7, 1, 8, 3, 4, 6, 2},
+				[]int{2, 7, 3, 4, 1, 0, 9, 5, 6},
+				[]int{1, 6, 4, 7, 5, 9, 0, 3, 0},
+				[]int{9, 8, 5, 0, 3, 0, 1, 0, 7},
+				[]int{8, 5, 9, 3, 7, 1, 6, 2, 4},
+				[]int{7, 0, 6, 0, 4, 5, 0, 1, 9},
+				[]int{4, 0, 1, 9, 6, 0, 0, 7, 5},
+			},
+		},
+		{
+			description: "hard, requires searching",
+			input: [][]int{
+				[]int{8, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 3, 6, 0, 0, 0, 0, 0},
+				[]int{0, 7, 0, 0, 9, 0, 2, 0, 0},
+				[]int{0, 5, 0, 0, 0, 7, 0, 0, 0},
+				[]int{0, 0, 0, 0, 4, 5, 7, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 0, 3, 0},
+				[]int{0, 0, 1, 0, 0, 0, 0, 6, 8},
+				[]int{0, 0, 8, 5, 0, 0, 0, 1, 0},
+				[]int{0, 9, 0, 0, 0, 0, 4, 0, 0},
+			},
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			dlxOutput, dlxCG, err := SolveGrid(copyGrid(td.input), WithEngine(EngineDLX))
+			require.Nil(t, err)
+			assert.Equal(t, CheckedGrid{Valid: true, Complete: true, Unique: true}, dlxCG)
+
+			logicalOutput, _, err := SolveGrid(copyGrid(td.input), WithEngine(EngineLogical))
+			require.Nil(t, err)
+			assert.Equal(t, dlxOutput, logicalOutput)
+		})
+	}
+}
+
+func TestCountSolutions(t *testing.T) {
+	tt := []struct {
+		description string
+		input       [][]int
+		limit       int
+		expectCount int
+	}{
+		{
+			description: "unique puzzle",
+			input: [][]int{
+				[]int{2, 0, 7, 0, 0, 6, 0, 0, 0},
+				[]int{0, 0, 0, 0, 3, 0, 2, 0, 6},
+				[]int{0, 5, 6, 0, 0, 2, 0, 4, 1},
+				[]int{1, 0, 0, 3, 0, 8, 7, 6, 0},
+				[]int{6, 0, 9, 0, 0, 0, 1, 0, 8},
+				[]int{0, 7, 4, 6, 0, 5, 0, 0, 3},
+				[]int{5, 8, 0, 7, 0, 0, 4, 1, 0},
+				[]int{9, 0, 1, 0, 5, 0, 0, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 3, 0, 5},
+			},
+			limit:       0,
+			expectCount: 1,
+		},
+		{
+			description: "many solutions, stops at the limit",
+			input: [][]int{
+				[]int{1, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 1, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 0, 0, 0},
+				[]int{0, 0, 1, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			limit:       5,
+			expectCount: 5,
+		},
+		{
+			description: "two solutions",
+			input: [][]int{
+				[]int{0, 0, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 0, 0, 3},
+				[]int{7, 8, 9, 0, 0, 3, 4, 5, 6},
+				[]int{0, 3, 4, 5, 6, 7, 8, 9, 0},
+				[]int{5, 6, 7, 8, 9, 0, 0, 3, 4},
+				[]int{8, 9, 0, 0, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 0, 0},
+				[]int{6, 7, 8, 9, 0, 0, 3, 4, 5},
+				[]int{9, 0, 0, 3, 4, 5, 6, 7, 8},
+			},
+			limit:       0,
+			expectCount: 2,
+		},
+		{
+			description: "no solution",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 9},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			limit:       0,
+			expectCount: 0,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			count, err := CountSolutions(td.input, td.limit)
+			require.Nil(t, err)
+			assert.Equal(t, td.expectCount, count)
+		})
+	}
+}
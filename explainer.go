@@ -0,0 +1,131 @@
+package soduku
+
+import (
+	"errors"
+)
+
+// ErrRequiresSearch is returned by ExplainerRating when the logical solver cannot finish the
+// puzzle. Sudoku Explainer rates such puzzles with nested forcing chains, which are not
+// implemented, so no comparable rating can be given
+var ErrRequiresSearch = errors.New("the puzzle cannot be rated without searching")
+
+// explainerWeights are the Sudoku Explainer ratings of the built in techniques. Hidden singles
+// are rated by hidden singles in a box, those in a row or column use explainerLineSingle.
+// Explainer has no w-wing, it finds the same eliminations with a short forcing chain. Nor has
+// it killer cages, so cage sums are rated like box line reductions, as Rate does
+var explainerWeights = map[Technique]float64{
+	HiddenSingle:       1.2,
+	NakedSingle:        2.3,
+	PointingCandidates: 2.6,
+	BoxLineReduction:   2.8,
+	CageSum:            2.8,
+	NakedPair:          3.0,
+	XWing:              3.2,
+	HiddenPair:         3.4,
+	NakedTriple:        3.6,
+	Swordfish:          3.8,
+	HiddenTriple:       4.0,
+	XYWing:             4.2,
+	XYZWing:            4.4,
+	UniqueRectangle1:   4.5,
+	UniqueRectangle4:   4.5,
+	UniqueRectangle2:   4.6,
+	UniqueRectangle3:   4.7,
+	NakedQuad:          5.0,
+	Jellyfish:          5.2,
+	HiddenQuad:         5.4,
+	BUGPlusOne:         5.6,
+	SimpleColoring:     6.5,
+	WWing:              6.6,
+	XChain:             6.6,
+	XYChain:            6.6,
+	AIC:                7.0,
+}
+
+// explainerLineSingle is the rating of a hidden single in a row, column or diagonal
+const explainerLineSingle = 1.5
+
+// explainerChainSteps are the chain lengths past which Explainer adds 0.1 to a chain's rating
+var explainerChainSteps = []int{4, 6, 8, 12, 16, 24, 32, 48, 64, 96, 128, 192, 256, 384, 512, 768, 1024}
+
+// ExplainerRating rates the puzzle on the same scale as Sudoku Explainer, so 1.2 for a puzzle
+// that only needs hidden singles in boxes up to 7.0 and beyond for chains. The puzzle is
+// solved by SolveGrid with the built in strategies tried in Explainer's order, cheapest first,
+// and the rating is that of the hardest step taken. Options are passed to SolveGrid, but the
+// strategies are always the built in ones.
+//
+// ErrRequiresSearch is returned, along with the rating of the logical steps, when the puzzle
+// needs searching to finish
+func ExplainerRating(grid [][]int, opts ...Option) (float64, error) {
+	geo, err := newOptions(opts).geometry(grid)
+	if err != nil {
+		return 0, err
+	}
+	log := &SolveLog{}
+	opts = append(append([]Option{}, opts...), WithSolveLog(log), WithEngine(EngineLogical), WithStrategies(explainerStrategies()...))
+	if _, _, err := SolveGrid(grid, opts...); err != nil {
+		return 0, err
+	}
+
+	// Replay the log to tell hidden singles in a box from those in a row or column
+	b := newCandidateBoard(geo, copyGrid(grid))
+	rating := 0.0
+	for i := range log.Steps {
+		s := &log.Steps[i]
+		if s.Technique == Search {
+			return rating, ErrRequiresSearch
+		}
+		if w := explainerWeight(b, s); w > rating {
+			rating = w
+		}
+		b.apply(s)
+	}
+	return rating, nil
+}
+
+// explainerWeight returns the rating of the step, taken on the board before it is applied
+func explainerWeight(b *CandidateBoard, s *Step) float64 {
+	w, ok := explainerWeights[s.Technique]
+	if !ok {
+		w = explainerWeights[AIC]
+	}
+	if s.Technique == HiddenSingle {
+		pos := position{rowNumber: s.Cell.Row, colNumber: s.Cell.Col}
+		box := b.geo.boxHouse(b.geo.regionNumber(pos.rowNumber, pos.colNumber))
+		if len(positionsAllowing(b, box, s.Digit)) != 1 {
+			w = explainerLineSingle
+		}
+	}
+	if s.Chain != nil {
+		for _, step := range explainerChainSteps {
+			if len(s.Chain.Nodes) > step {
+				w += 0.1
+			}
+		}
+	}
+	// Round to one decimal place, as the sums of tenths are not exact
+	return float64(int(w*10+0.5)) / 10
+}
+
+// explainerStrategies returns the built in strategies in the order of their Explainer rating,
+// with hidden singles in boxes tried before those in any other unit
+func explainerStrategies() []Strategy {
+	strategies := []Strategy{
+		NewStrategy(HiddenSingle, findHiddenSingleIn(UnitBox)),
+		NewStrategy(HiddenSingle, findHiddenSingle),
+	}
+	order := []Technique{
+		NakedSingle, PointingCandidates, BoxLineReduction, CageSum, NakedPair, XWing, HiddenPair,
+		NakedTriple, Swordfish, HiddenTriple, XYWing, XYZWing, UniqueRectangle1,
+		UniqueRectangle4, UniqueRectangle2, UniqueRectangle3, NakedQuad, Jellyfish, HiddenQuad,
+		BUGPlusOne, SimpleColoring, WWing, XChain, XYChain, AIC,
+	}
+	for _, name := range order {
+		for _, t := range logicalTechniques {
+			if t.name == name {
+				strategies = append(strategies, t)
+			}
+		}
+	}
+	return strategies
+}
@@ -0,0 +1,78 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainerRating(t *testing.T) {
+	tt := []struct {
+		description  string
+		input        string
+		expectRating float64
+	}{
+		{
+			description:  "x-wing",
+			input:        "007080002290100408060020703480006000000098000000400039004009010030070006001000000",
+			expectRating: 3.2,
+		},
+		{
+			description:  "naked quad",
+			input:        "000000507000019008040300900600004000207108006000000800890040250000090000005200300",
+			expectRating: 5.0,
+		},
+		{
+			description:  "jellyfish",
+			input:        "200000003080030050003402100001205400000090000009308600002506900090020070400000001",
+			expectRating: 5.2,
+		},
+		{
+			description:  "chains",
+			input:        "280006000006500000500908000010407005603090000000800000000300050900000087030002010",
+			expectRating: 7.3,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+
+			rating, err := g.ExplainerRating()
+			require.Nil(t, err)
+			assert.Equal(t, td.expectRating, rating)
+		})
+	}
+}
+
+func TestExplainerRatingKeepsOptions(t *testing.T) {
+	g, err := ParseGrid(readmeGrid)
+	require.Nil(t, err)
+
+	// The options ExplainerRating adds must not land in the room left after the caller's
+	opts := make([]Option, 1, 4)
+	opts[0] = WithEngine(EngineDLX)
+	_, err = ExplainerRating(g.Rows(), opts...)
+	require.Nil(t, err)
+	for _, o := range opts[1:cap(opts)] {
+		assert.Nil(t, o)
+	}
+}
+
+func TestExplainerWeight(t *testing.T) {
+	// The first row only has its first square left for 1, but the box still allows it
+	// elsewhere, so the hidden single is in a row
+	grid := emptyTestGrid()
+	grid[0] = []int{0, 2, 3, 4, 5, 6, 7, 8, 9}
+	b := newCandidateBoard(newGeometry(3, 3), grid)
+	single := hiddenSingle(b, b.geo.houses[0])
+	require.NotNil(t, single)
+	assert.Equal(t, Cell{Row: 0, Col: 0}, single.Cell)
+	assert.Equal(t, explainerLineSingle, explainerWeight(b, single))
+
+	// Chains gain 0.1 for each length step they pass
+	chain := &Step{Technique: XChain, Chain: &Chain{Nodes: make([]Candidate, 7)}}
+	assert.Equal(t, 6.8, explainerWeight(b, chain))
+}
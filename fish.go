@@ -0,0 +1,103 @@
+package soduku
+
+import (
+	"math/bits"
+)
+
+const (
+	// XWing removes a number from two columns when, in two rows, it can only go in those
+	// columns. The same applies with rows and columns swapped
+	XWing Technique = "x-wing"
+	// Swordfish is an x-wing with three rows and three columns
+	Swordfish Technique = "swordfish"
+	// Jellyfish is an x-wing with four rows and four columns
+	Jellyfish Technique = "jellyfish"
+)
+
+// fishes maps the size of a fish to its technique
+var fishes = map[int]Technique{2: XWing, 3: Swordfish, 4: Jellyfish}
+
+// findFish returns a technique finding the first fish of the size, looking at rows as the
+// base sets before columns
+func findFish(size int) func(b *CandidateBoard) *Step {
+	return func(b *CandidateBoard) *Step {
+		for _, kind := range []UnitKind{UnitRow, UnitColumn} {
+			if step := fish(b, size, kind); step != nil {
+				return step
+			}
+		}
+		return nil
+	}
+}
+
+// fish looks for size lines of the base kind, rows or columns, where a number can only go in
+// the same size crossing lines. Each base line has to hold the number in one of the crossing
+// lines, so between them they fill every crossing line and the number is removed from the
+// rest of them. It returns nil if no fish would remove anything
+func fish(b *CandidateBoard, size int, base UnitKind) *Step {
+	n := b.geo.size
+	baseLine, coverLine := b.geo.rowHouse, b.geo.colHouse
+	if base == UnitColumn {
+		baseLine, coverLine = b.geo.colHouse, b.geo.rowHouse
+	}
+
+	for num := 1; num <= n; num++ {
+		// Each line that allows the number in at most size squares, with a mask of the crossing
+		// lines it can go in
+		lines := []int{}
+		masks := []uint32{}
+		for i := 0; i < n; i++ {
+			var mask uint32
+			for j, pos := range baseLine(i).positions {
+				if b.candidates(pos).has(num) {
+					mask |= 1 << uint(j)
+				}
+			}
+			if count := bits.OnesCount32(mask); count >= 2 && count <= size {
+				lines = append(lines, i)
+				masks = append(masks, mask)
+			}
+		}
+
+		var step *Step
+		eachCombination(len(lines), size, func(picked []int) bool {
+			var union uint32
+			inBase := map[int]bool{}
+			for _, i := range picked {
+				union |= masks[i]
+				inBase[lines[i]] = true
+			}
+			if bits.OnesCount32(union) != size {
+				return false
+			}
+
+			s := &Step{Technique: fishes[size], Eliminations: []Candidate{}}
+			for _, i := range picked {
+				h := baseLine(lines[i])
+				s.BaseSets = append(s.BaseSets, h.Unit)
+				s.Reasons = append(s.Reasons, cellsOf(positionsAllowing(b, h, num))...)
+			}
+			for j := 0; j < n; j++ {
+				if union&(1<<uint(j)) == 0 {
+					continue
+				}
+				h := coverLine(j)
+				s.CoverSets = append(s.CoverSets, h.Unit)
+				for k, pos := range h.positions {
+					if !inBase[k] && b.candidates(pos).has(num) {
+						s.Eliminations = append(s.Eliminations, candidatesOf(pos, 1<<uint(num))...)
+					}
+				}
+			}
+			if len(s.Eliminations) == 0 {
+				return false
+			}
+			step = s
+			return true
+		})
+		if step != nil {
+			return step
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveGridFish(t *testing.T) {
+	tt := []struct {
+		description     string
+		input           string
+		expectTechnique Technique
+	}{
+		{
+			description:     "x-wing",
+			input:           "007080002290100408060020703480006000000098000000400039004009010030070006001000000",
+			expectTechnique: XWing,
+		},
+		{
+			description:     "swordfish",
+			input:           "090034000060082053000000080900000000001003500280071000000050470008000095010008000",
+			expectTechnique: Swordfish,
+		},
+		{
+			description:     "jellyfish",
+			input:           "200000003080030050003402100001205400000090000009308600002506900090020070400000001",
+			expectTechnique: Jellyfish,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+
+			log := &SolveLog{}
+			solved, _, err := SolveGrid(g.Rows(), WithSolveLog(log))
+			require.Nil(t, err)
+
+			techniques := replaySolveLog(t, g.Rows(), solved, log)
+			assert.True(t, techniques[td.expectTechnique] > 0)
+			assert.Equal(t, 0, techniques[Search])
+		})
+	}
+}
+
+func TestFish(t *testing.T) {
+	// Remove 1 from the first and fifth rows everywhere but the first and fifth columns. The
+	// two rows fill both columns with a 1, so it can go nowhere else in them
+	b := newCandidateBoard(newGeometry(3, 3), emptyTestGrid())
+	for _, row := range []int{0, 4} {
+		for col := 0; col <= 8; col++ {
+			if col != 0 && col != 4 {
+				b.apply(&Step{Eliminations: []Candidate{{Cell: Cell{Row: row, Col: col}, Digit: 1}}})
+			}
+		}
+	}
+
+	assert.Nil(t, fish(b, 2, UnitColumn))
+	step := fish(b, 2, UnitRow)
+	require.NotNil(t, step)
+	assert.Equal(t, XWing, step.Technique)
+	assert.Equal(t, []Unit{{Kind: UnitRow, Index: 0}, {Kind: UnitRow, Index: 4}}, step.BaseSets)
+	assert.Equal(t, []Unit{{Kind: UnitColumn, Index: 0}, {Kind: UnitColumn, Index: 4}}, step.CoverSets)
+	assert.Equal(t, []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 4}, {Row: 4, Col: 0}, {Row: 4, Col: 4}},
+		step.Reasons)
+	assert.Len(t, step.Eliminations, 14)
+	for _, e := range step.Eliminations {
+		assert.Equal(t, 1, e.Digit)
+		assert.NotContains(t, []int{0, 4}, e.Row)
+	}
+	assert.Equal(t, "x-wing: r2c1<>1, r3c1<>1, r4c1<>1, r6c1<>1, r7c1<>1, r8c1<>1, r9c1<>1, "+
+		"r2c5<>1, r3c5<>1, r4c5<>1, r6c5<>1, r7c5<>1, r8c5<>1, r9c5<>1 [row 0, row 4 / column 0, column 4] "+
+		"(r1c1, r1c5, r5c1, r5c5)", step.String())
+}
@@ -0,0 +1,182 @@
+package soduku
+
+import (
+	"fmt"
+)
+
+// maxSize is the largest grid supported, every number has to fit in a digitSet
+const maxSize = 25
+
+// geometry is the layout of a grid: its size, its regions and the houses that must each hold
+// every number once. The houses are every row, then every column, then every region, then any
+// extra units of a variant such as the diagonals. Regions are the boxes in reading order, or
+// the pieces of a jigsaw layout
+type geometry struct {
+	size int
+	// digits holds every number from 1 to size
+	digits  digitSet
+	regions []region
+	houses  []house
+	// regionOf is the index in regions of the box holding each square
+	regionOf [][]int
+	// housesAt is the indexes in houses of the houses holding each square
+	housesAt [][][]int
+	// cages are the cages of a killer grid, they are not houses as they do not hold every
+	// number
+	cages []cage
+	// cageOf is the index in cages of the cage holding each square, or -1
+	cageOf [][]int
+}
+
+// defaultBoxShape returns the box shape used for a grid of the size when none is given. It
+// picks the squarest boxes that tile the grid with at least two rows, so 2x3 for a 6x6 grid
+// and 3x4 for a 12x12 grid. It returns false if no box shape fits
+func defaultBoxShape(size int) (rows, cols int, ok bool) {
+	if size > maxSize {
+		return 0, 0, false
+	}
+	for r := 2; r*r <= size; r++ {
+		if size%r == 0 {
+			rows, cols, ok = r, size/r, true
+		}
+	}
+	return rows, cols, ok
+}
+
+// boxRegions returns the boxes of boxRows by boxCols tiling a grid, in reading order
+func boxRegions(boxRows, boxCols int) []region {
+	size := boxRows * boxCols
+	regions := []region{}
+	for row := 0; row < size; row += boxRows {
+		for col := 0; col < size; col += boxCols {
+			reg := region{}
+			for r := row; r < row+boxRows; r++ {
+				for c := col; c < col+boxCols; c++ {
+					reg = append(reg, position{rowNumber: r, colNumber: c})
+				}
+			}
+			regions = append(regions, reg)
+		}
+	}
+	return regions
+}
+
+// regionGeometry builds the geometry of a size x size grid split into the regions, which must
+// cover every square once. The extra houses are added after the regions
+func regionGeometry(size int, regions []region, extra ...house) *geometry {
+	g := &geometry{size: size, digits: digitsTo(size), regions: regions}
+	g.houses = append(buildHouses(size, regions), extra...)
+
+	g.regionOf = make([][]int, size)
+	g.housesAt = make([][][]int, size)
+	g.cageOf = make([][]int, size)
+	for row := range g.housesAt {
+		g.regionOf[row] = make([]int, size)
+		g.housesAt[row] = make([][]int, size)
+		g.cageOf[row] = make([]int, size)
+		for col := range g.cageOf[row] {
+			g.cageOf[row][col] = -1
+		}
+	}
+	for i, h := range g.houses {
+		for _, pos := range h.positions {
+			g.housesAt[pos.rowNumber][pos.colNumber] = append(g.housesAt[pos.rowNumber][pos.colNumber], i)
+			if h.Kind == UnitBox {
+				g.regionOf[pos.rowNumber][pos.colNumber] = h.Index
+			}
+		}
+	}
+	return g
+}
+
+// regionNumber returns the index in regions of the box that holds the position
+func (g *geometry) regionNumber(row, col int) int {
+	return g.regionOf[row][col]
+}
+
+// rowHouse returns the house of the row
+func (g *geometry) rowHouse(row int) house {
+	return g.houses[row]
+}
+
+// colHouse returns the house of the column
+func (g *geometry) colHouse(col int) house {
+	return g.houses[g.size+col]
+}
+
+// boxHouse returns the house of the box with the index in regions
+func (g *geometry) boxHouse(regNum int) house {
+	return g.houses[2*g.size+regNum]
+}
+
+// lines returns the houses that are not regions, every row, then every column, then the
+// extra units
+func (g *geometry) lines() []house {
+	lines := append([]house{}, g.houses[:2*g.size]...)
+	return append(lines, g.houses[2*g.size+len(g.regions):]...)
+}
+
+// housesOf returns every house holding the position, its row, column and box first
+func (g *geometry) housesOf(pos position) []house {
+	houses := []house{}
+	for _, i := range g.housesAt[pos.rowNumber][pos.colNumber] {
+		houses = append(houses, g.houses[i])
+	}
+	return houses
+}
+
+// sharedHouses returns the houses holding both squares
+func (g *geometry) sharedHouses(a, b position) []house {
+	shared := []house{}
+	for _, h := range g.housesOf(a) {
+		if containsPosition(h.positions, b) {
+			shared = append(shared, h)
+		}
+	}
+	return shared
+}
+
+// sees returns whether two different squares share a house or a cage, so cannot hold the same
+// number
+func (g *geometry) sees(a, b position) bool {
+	if a == b {
+		return false
+	}
+	for _, i := range g.housesAt[a.rowNumber][a.colNumber] {
+		for _, j := range g.housesAt[b.rowNumber][b.colNumber] {
+			if i == j {
+				return true
+			}
+		}
+	}
+	c := g.cageOf[a.rowNumber][a.colNumber]
+	return c >= 0 && c == g.cageOf[b.rowNumber][b.colNumber]
+}
+
+// onGrid returns whether the cell is inside the grid
+func (g *geometry) onGrid(c Cell) bool {
+	return c.Row >= 0 && c.Row < g.size && c.Col >= 0 && c.Col < g.size
+}
+
+// describe names the house in the messages of CheckGrid
+func (g *geometry) describe(h house) string {
+	switch h.Kind {
+	case UnitRow:
+		return fmt.Sprintf("row %d", h.Index)
+	case UnitColumn:
+		return fmt.Sprintf("column %d", h.Index)
+	case UnitDiagonal:
+		if h.Index == 0 {
+			return "the diagonal from the top left"
+		}
+		return "the diagonal from the top right"
+	case UnitExtra:
+		return fmt.Sprintf("extra unit %d", h.Index)
+	}
+	minRow, maxRow, minCol, maxCol, rect := g.regions[h.Index].bounds()
+	if !rect {
+		return fmt.Sprintf("region %d", h.Index)
+	}
+	gridPosition := fmt.Sprintf("rowNumber {%d, %d}, colNumber {%d, %d}", minRow, maxRow, minCol, maxCol)
+	return fmt.Sprintf("grid %q", gridPosition)
+}
@@ -0,0 +1,209 @@
+package soduku
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultBoxShape(t *testing.T) {
+	tt := []struct {
+		size       int
+		expectRows int
+		expectCols int
+		expectOK   bool
+	}{
+		{size: 4, expectRows: 2, expectCols: 2, expectOK: true},
+		{size: 6, expectRows: 2, expectCols: 3, expectOK: true},
+		{size: 9, expectRows: 3, expectCols: 3, expectOK: true},
+		{size: 12, expectRows: 3, expectCols: 4, expectOK: true},
+		{size: 16, expectRows: 4, expectCols: 4, expectOK: true},
+		{size: 25, expectRows: 5, expectCols: 5, expectOK: true},
+		{size: 1},
+		{size: 7},
+		{size: 36},
+	}
+
+	for _, td := range tt {
+		rows, cols, ok := defaultBoxShape(td.size)
+		assert.Equal(t, td.expectOK, ok, "size %d", td.size)
+		assert.Equal(t, td.expectRows, rows, "size %d", td.size)
+		assert.Equal(t, td.expectCols, cols, "size %d", td.size)
+	}
+}
+
+func TestNewGeometry(t *testing.T) {
+	geo := newGeometry(2, 3)
+	assert.Equal(t, 6, geo.size)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, geo.digits.numbers())
+	assert.Len(t, geo.houses, 18)
+	assert.Equal(t, boxRegion(2, 3, 3, 5), geo.regions[3])
+	assert.Equal(t, 3, geo.regionNumber(3, 4))
+	assert.True(t, geo.sees(position{rowNumber: 2, colNumber: 3}, position{rowNumber: 3, colNumber: 5}))
+	assert.False(t, geo.sees(position{rowNumber: 1, colNumber: 3}, position{rowNumber: 2, colNumber: 5}))
+}
+
+func TestSolveGridSizes(t *testing.T) {
+	tt := []struct {
+		description  string
+		input        string
+		opts         []Option
+		expectOutput string
+	}{
+		{
+			description:  "4x4",
+			input:        "......31 1......2",
+			expectOutput: "3124 2431 1243 4312",
+		},
+		{
+			description:  "6x6",
+			input:        "...6.. .345.. 12..4. ..5... ....5. 4.1...",
+			expectOutput: "512634 634512 126345 345126 263451 451263",
+		},
+		{
+			// The 6x6 puzzle above turned on its side
+			description:  "6x6 with 3x2 boxes",
+			input:        "..1..4 .32... .4.5.1 65.... ..4.5. ......",
+			opts:         []Option{WithBoxShape(3, 2)},
+			expectOutput: "561324 132465 246531 653142 314256 425613",
+		},
+		{
+			description: "16x16",
+			input: `
+				8.AE.6.F9...5B.D G.2F.C..5.4.8.AE .C..5B4..7..G.2. 5B4D..A.G..F9.1.
+				7AEG62F...35..D8 ....C.35B..8...G C13.B.D8.A...... .4D.7AEG...9C135
+				A.G6.F9C......8. 2F9.1..B4..7A..6 13..4D...E..2F.C 4..7..G62..C.35.
+				.G..F.C135B4D87. F..135B4D8..E..2 35.....A...2F.C1 D.7..G...9C13...
+			`,
+			expectOutput: `
+				87AEG62F9C135B4D G62F9C135B4D87AE 9C135B4D87AEG62F 5B4D87AEG62F9C13
+				7AEG62F9C135B4D8 62F9C135B4D87AEG C135B4D87AEG62F9 B4D87AEG62F9C135
+				AEG62F9C135B4D87 2F9C135B4D87AEG6 135B4D87AEG62F9C 4D87AEG62F9C135B
+				EG62F9C135B4D87A F9C135B4D87AEG62 35B4D87AEG62F9C1 D87AEG62F9C135B4
+			`,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+			expect, err := ParseGrid(td.expectOutput)
+			require.Nil(t, err)
+
+			solved, cg, err := g.Solve(td.opts...)
+			require.Nil(t, err)
+			assert.Equal(t, CheckedGrid{Valid: true, Complete: true, Unique: true}, cg)
+			assert.Equal(t, expect, solved)
+
+			// The string form reads back to the same grid
+			roundTrip, err := ParseGrid(g.String())
+			require.Nil(t, err)
+			assert.Equal(t, g, roundTrip)
+		})
+	}
+}
+
+func TestSolveGridLargeSizes(t *testing.T) {
+	for _, shape := range [][2]int{{3, 4}, {5, 5}} {
+		solution := patternGrid(shape[0], shape[1])
+		input := copyGrid(solution)
+		for i := range input {
+			input[i][(i*7)%len(input)] = 0
+		}
+
+		solved, cg, err := SolveGrid(input)
+		require.Nil(t, err)
+		assert.True(t, cg.Valid)
+		assert.True(t, cg.Complete)
+		assert.Equal(t, solution, solved)
+	}
+}
+
+func TestSolveGridLargeEmpty(t *testing.T) {
+	// Every square of an empty grid is left to the search, which must still keep up with the
+	// largest sizes
+	for _, shape := range [][2]int{{4, 5}, {5, 5}} {
+		size := shape[0] * shape[1]
+		grid := make([][]int, size)
+		for row := range grid {
+			grid[row] = make([]int, size)
+		}
+
+		var solved [][]int
+		var cg CheckedGrid
+		var err error
+		done := make(chan struct{})
+		go func() {
+			solved, cg, err = SolveGrid(grid)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(20 * time.Second):
+			t.Fatalf("solving an empty %dx%d grid took too long", size, size)
+		}
+		require.Nil(t, err)
+		assert.True(t, cg.Complete)
+		assert.False(t, cg.Unique)
+		assert.True(t, CheckGrid(solved).Valid)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		count := 0
+		err = EachSolution(ctx, grid, func(solution [][]int) bool {
+			assert.True(t, CheckGrid(solution).Complete)
+			count++
+			return count < 2
+		})
+		cancel()
+		require.Nil(t, err)
+		assert.Equal(t, 2, count)
+	}
+}
+
+func TestCheckGridBoxShape(t *testing.T) {
+	// Valid with 3x2 boxes, but the default 2x3 boxes hold duplicates
+	grid, err := ParseGrid("561324 132465 246531 653142 314256 425613")
+	require.Nil(t, err)
+	assert.Equal(t, CheckedGrid{Valid: true, Complete: true}, grid.Check(WithBoxShape(3, 2)))
+	cg := grid.Check()
+	assert.False(t, cg.Valid)
+	assert.Equal(t, Unit{Kind: UnitBox, Index: 0}, cg.Conflicts[0].Unit)
+
+	cg = grid.Check(WithBoxShape(3, 3))
+	assert.False(t, cg.Valid)
+	assert.Equal(t, "expected 9 rows, found 6", cg.Message)
+
+	cg = grid.Check(WithBoxShape(0, 6))
+	assert.False(t, cg.Valid)
+	assert.Equal(t, "invalid box shape 0x6", cg.Message)
+
+	// A grid of any size can be built, but without a layout it needs boxes to be checked
+	grid, err = NewGrid([][]int{{1, 2, 3, 4, 5}, {0, 0, 0, 0, 0}, {0, 0, 0, 0, 0}, {0, 0, 0, 0, 0}, {0, 0, 0, 0, 0}})
+	require.Nil(t, err)
+	cg = grid.Check()
+	assert.False(t, cg.Valid)
+	assert.Equal(t, "no boxes fit a grid of 5 rows", cg.Message)
+}
+
+// newGeometry builds the geometry of a grid tiled by boxes of boxRows by boxCols, the grid has
+// boxRows*boxCols rows and columns
+func newGeometry(boxRows, boxCols int) *geometry {
+	return regionGeometry(boxRows*boxCols, boxRegions(boxRows, boxCols))
+}
+
+// patternGrid returns a complete grid with boxes of boxRows by boxCols
+func patternGrid(boxRows, boxCols int) [][]int {
+	size := boxRows * boxCols
+	grid := make([][]int, size)
+	for row := range grid {
+		grid[row] = make([]int, size)
+		for col := range grid[row] {
+			grid[row][col] = (boxCols*(row%boxRows)+row/boxRows+col)%size + 1
+		}
+	}
+	return grid
+}
@@ -0,0 +1,198 @@
+package soduku
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Grid is a sudoku grid that has been checked for shape and range, empty squares hold 0. It
+// has as many rows as columns, 9 for a classic grid and at most 25. Whether its boxes or
+// regions fit is left to the options it is solved or checked with, so a jigsaw grid can have
+// any size. Grids are built with NewGrid or ParseGrid, the zero Grid is treated as invalid
+type Grid struct {
+	cells [][]int
+}
+
+// ShapeError is returned when a grid does not have as many numbers in every row as it has
+// rows, or when no boxes fit its size
+type ShapeError struct {
+	// Row is the row with the wrong number of columns, or -1 if the number of rows is wrong
+	Row int
+	// Length is the number of columns in Row, or the number of rows if Row is -1
+	Length int
+	// Size is the number of rows and columns expected, or 0 if no size was expected
+	Size int
+}
+
+func (e *ShapeError) Error() string {
+	switch {
+	case e.Row >= 0:
+		return fmt.Sprintf("expected %d columns in row %d, found %d", e.Size, e.Row, e.Length)
+	case e.Size > 0:
+		return fmt.Sprintf("expected %d rows, found %d", e.Size, e.Length)
+	}
+	return fmt.Sprintf("no boxes fit a grid of %d rows", e.Length)
+}
+
+// NumberError is returned when a square holds a number outside 0 to the size of the grid
+type NumberError struct {
+	Row    int
+	Col    int
+	Number int
+}
+
+func (e *NumberError) Error() string {
+	return fmt.Sprintf("invalid number %d at row %d column %d", e.Number, e.Row, e.Col)
+}
+
+// SyntaxError is returned by ParseGrid when the string holds a character that is not a square
+type SyntaxError struct {
+	// Offset is the byte offset of the character in the string
+	Offset int
+	Char   rune
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("unexpected character %q at offset %d", e.Char, e.Offset)
+}
+
+// NewGrid checks that cells has as many numbers in every row as it has rows, and that they
+// are between 0 and the number of rows, then returns them as a Grid. The cells are copied, so
+// later changes to them do not affect the Grid
+func NewGrid(cells [][]int) (Grid, error) {
+	if len(cells) == 0 || len(cells) > maxSize {
+		return Grid{}, &ShapeError{Row: -1, Length: len(cells)}
+	}
+	if err := validateGrid(cells, len(cells)); err != nil {
+		return Grid{}, err
+	}
+	return Grid{cells: copyGrid(cells)}, nil
+}
+
+// ParseGrid reads a grid from a string of squares in reading order, 81 of them for a 9x9
+// grid. Squares are the numbers 1 to 9 followed by the letters A to P for 10 to 25, in either
+// case, with 0 or . for an empty square. Whitespace is ignored, so the grid can be written on
+// one line or as one line per row
+func ParseGrid(s string) (Grid, error) {
+	nums := []int{}
+	for offset, c := range s {
+		var num int
+		switch {
+		case unicode.IsSpace(c):
+			continue
+		case c == '.':
+			num = 0
+		case c >= '0' && c <= '9':
+			num = int(c - '0')
+		case c >= 'A' && c < 'A'+maxSize-9:
+			num = int(c-'A') + 10
+		case c >= 'a' && c < 'a'+maxSize-9:
+			num = int(c-'a') + 10
+		default:
+			return Grid{}, &SyntaxError{Offset: offset, Char: c}
+		}
+		nums = append(nums, num)
+	}
+
+	// The rows are as long as the smallest grid that holds every square
+	size := 1
+	for size*size < len(nums) {
+		size++
+	}
+	cells := [][]int{}
+	for len(nums) > 0 {
+		n := size
+		if n > len(nums) {
+			n = len(nums)
+		}
+		cells = append(cells, nums[:n])
+		nums = nums[n:]
+	}
+	return NewGrid(cells)
+}
+
+// validateGrid returns an error if the grid is not size rows of size numbers between 0 and
+// size
+func validateGrid(grid [][]int, size int) error {
+	if len(grid) != size {
+		return &ShapeError{Row: -1, Length: len(grid), Size: size}
+	}
+	for rowNum, row := range grid {
+		if len(row) != size {
+			return &ShapeError{Row: rowNum, Length: len(row), Size: size}
+		}
+	}
+	for rowNum, row := range grid {
+		for colNum, num := range row {
+			if num < 0 || num > size {
+				return &NumberError{Row: rowNum, Col: colNum, Number: num}
+			}
+		}
+	}
+	return nil
+}
+
+// Rows returns a copy of the squares in the grid
+func (g Grid) Rows() [][]int {
+	return copyGrid(g.cells)
+}
+
+// Get returns the number at the position, 0 if the square is empty or the position is off
+// the grid, as it is for every position of the zero Grid
+func (g Grid) Get(row, col int) int {
+	if row < 0 || row >= len(g.cells) || col < 0 || col >= len(g.cells) {
+		return 0
+	}
+	return g.cells[row][col]
+}
+
+// Solve is the Grid form of SolveGrid
+func (g Grid) Solve(opts ...Option) (Grid, CheckedGrid, error) {
+	solved, cg, err := SolveGrid(g.cells, opts...)
+	if solved == nil {
+		return g, cg, err
+	}
+	return Grid{cells: solved}, cg, err
+}
+
+// Rate is the Grid form of Rate
+func (g Grid) Rate(opts ...Option) (Rating, error) {
+	return Rate(g.cells, opts...)
+}
+
+// ExplainerRating is the Grid form of ExplainerRating
+func (g Grid) ExplainerRating(opts ...Option) (float64, error) {
+	return ExplainerRating(g.cells, opts...)
+}
+
+// Check is the Grid form of CheckGrid
+func (g Grid) Check(opts ...Option) CheckedGrid {
+	return CheckGrid(g.cells, opts...)
+}
+
+// Print is the Grid form of PrintGrid
+func (g Grid) Print() {
+	PrintGrid(g.cells)
+}
+
+// String returns the grid as one line per row, with . for an empty square and letters for
+// numbers above 9. The output can be read back with ParseGrid
+func (g Grid) String() string {
+	var sb strings.Builder
+	for _, row := range g.cells {
+		for _, num := range row {
+			if num == 0 {
+				sb.WriteByte('.')
+				continue
+			}
+			if num > 9 {
+				sb.WriteByte(byte('A' + num - 10))
+				continue
+			}
+			sb.WriteByte(byte('0' + num))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
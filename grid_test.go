@@ -0,0 +1,179 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGrid(t *testing.T) {
+	tt := []struct {
+		description string
+		input       [][]int
+		expectErr   error
+	}{
+		{
+			description: "valid",
+			input: [][]int{
+				[]int{0, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 1, 2, 3},
+				[]int{7, 8, 9, 1, 2, 3, 4, 5, 6},
+				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
+				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
+			},
+		},
+		{
+			description: "too few rows",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			},
+			expectErr: &ShapeError{Row: 0, Length: 9, Size: 1},
+		},
+		{
+			description: "no rows",
+			input:       [][]int{},
+			expectErr:   &ShapeError{Row: -1, Length: 0},
+		},
+		{
+			description: "short row",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 1, 2, 3},
+				[]int{7, 8, 9, 1, 2, 3, 4, 5, 6},
+				[]int{2, 3, 4, 5, 6, 7, 8, 9},
+				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
+			},
+			expectErr: &ShapeError{Row: 3, Length: 8, Size: 9},
+		},
+		{
+			description: "number out of range",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 1, 2, 3},
+				[]int{7, 8, 9, 1, 2, 3, 4, 5, 6},
+				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
+				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+				[]int{9, 1, 2, 3, 4, 5, 6, 7, 10},
+			},
+			expectErr: &NumberError{Row: 8, Col: 8, Number: 10},
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := NewGrid(td.input)
+			assert.Equal(t, td.expectErr, err)
+			if td.expectErr == nil {
+				assert.Equal(t, td.input, g.Rows())
+			}
+		})
+	}
+}
+
+func TestParseGrid(t *testing.T) {
+	tt := []struct {
+		description string
+		input       string
+		expectRows  [][]int
+		expectErr   error
+	}{
+		{
+			description: "one line",
+			input:       "2.7..6.......3.2.6.56..2.411..3.876.6.9...1.8.746.5..358.7..41.9.1.5.......1..3.5",
+			expectRows: [][]int{
+				[]int{2, 0, 7, 0, 0, 6, 0, 0, 0},
+				[]int{0, 0, 0, 0, 3, 0, 2, 0, 6},
+				[]int{0, 5, 6, 0, 0, 2, 0, 4, 1},
+				[]int{1, 0, 0, 3, 0, 8, 7, 6, 0},
+				[]int{6, 0, 9, 0, 0, 0, 1, 0, 8},
+				[]int{0, 7, 4, 6, 0, 5, 0, 0, 3},
+				[]int{5, 8, 0, 7, 0, 0, 4, 1, 0},
+				[]int{9, 0, 1, 0, 5, 0, 0, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 3, 0, 5},
+			},
+		},
+		{
+			description: "nine lines",
+			input: `
+				207006000
+				000030206
+				056002041
+				100308760
+				609000108
+				074605003
+				580700410
+				901050000
+				000100305
+			`,
+			expectRows: [][]int{
+				[]int{2, 0, 7, 0, 0, 6, 0, 0, 0},
+				[]int{0, 0, 0, 0, 3, 0, 2, 0, 6},
+				[]int{0, 5, 6, 0, 0, 2, 0, 4, 1},
+				[]int{1, 0, 0, 3, 0, 8, 7, 6, 0},
+				[]int{6, 0, 9, 0, 0, 0, 1, 0, 8},
+				[]int{0, 7, 4, 6, 0, 5, 0, 0, 3},
+				[]int{5, 8, 0, 7, 0, 0, 4, 1, 0},
+				[]int{9, 0, 1, 0, 5, 0, 0, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 3, 0, 5},
+			},
+		},
+		{
+			description: "too short",
+			input:       "2.7..6.......3.2.6.56..2.411..3.876.6.9...1.8.746.5..358.7..41.9.1.5.......1..3.",
+			expectErr:   &ShapeError{Row: 8, Length: 8, Size: 9},
+		},
+		{
+			description: "unexpected character",
+			input:       "2.7..6..x",
+			expectErr:   &SyntaxError{Offset: 8, Char: 'x'},
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			assert.Equal(t, td.expectErr, err)
+			if td.expectErr == nil {
+				assert.Equal(t, td.expectRows, g.Rows())
+
+				// The string form reads back to the same grid
+				roundTrip, err := ParseGrid(g.String())
+				require.Nil(t, err)
+				assert.Equal(t, g, roundTrip)
+			}
+		})
+	}
+}
+
+func TestGridSolve(t *testing.T) {
+	g, err := ParseGrid("2.7..6.......3.2.6.56..2.411..3.876.6.9...1.8.746.5..358.7..41.9.1.5.......1..3.5")
+	require.Nil(t, err)
+
+	solved, cg, err := g.Solve()
+	require.Nil(t, err)
+	assert.Equal(t, CheckedGrid{Valid: true, Complete: true, Unique: true}, cg)
+	assert.Equal(t, CheckedGrid{Valid: true, Complete: true}, solved.Check())
+	assert.Equal(t, 1, solved.Get(0, 1))
+	assert.Equal(t, 0, g.Get(0, 1))
+	for _, pos := range [][2]int{{-1, 0}, {0, -1}, {9, 0}, {0, 9}} {
+		assert.Equal(t, 0, solved.Get(pos[0], pos[1]))
+	}
+
+	// The zero Grid is reported as invalid rather than panicking
+	_, _, err = Grid{}.Solve()
+	assert.Equal(t, &ShapeError{Row: -1, Length: 0}, err)
+	assert.False(t, Grid{}.Check().Valid)
+	assert.Equal(t, 0, Grid{}.Get(0, 0))
+}
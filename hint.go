@@ -0,0 +1,67 @@
+package soduku
+
+import (
+	"errors"
+)
+
+// ErrGridComplete is returned by Hint when there are no empty squares left
+var ErrGridComplete = errors.New("the grid is already complete")
+
+// Hint returns the single easiest next step for the grid, without solving the rest of it. The
+// step uses the same techniques as SolveGrid, tried from easiest to hardest. When candidates
+// have to be removed before any number can be placed, those elimination steps are returned in
+// the Prerequisites of the placement. When no logical step applies, the returned step has the
+// technique GuessRequired and holds the number from the solution for the empty square with
+// the fewest possible numbers.
+//
+// Options give the layout of the grid and change the techniques tried, as for SolveGrid. The
+// grid is not modified. ErrNoSolution is returned if the grid cannot be completed
+func Hint(grid [][]int, opts ...Option) (Step, error) {
+	o := newOptions(opts)
+	geo, err := o.geometry(grid)
+	if err != nil {
+		return Step{}, err
+	}
+	cg := checkGrid(geo, grid)
+	if !cg.Valid {
+		return Step{}, errors.New("the grid is invalid")
+	}
+	if cg.Complete {
+		return Step{}, ErrGridComplete
+	}
+
+	// A logical step on a grid with no solution would be misleading, so find the solution first
+	solved, err := solveDLX(geo, grid)
+	if err != nil {
+		return Step{}, err
+	}
+
+	// Uniqueness techniques could remove the answer from a puzzle with more than one solution
+	o.uniqueness = o.uniqueness && countSolutions(geo, grid, 2) == 1
+
+	// Steps that only remove candidates are made in turn until a number can be placed
+	b := newCandidateBoard(geo, copyGrid(grid))
+	strategies := o.strategies()
+	var prerequisites []Step
+	for {
+		step := nextStep(b, strategies)
+		if step == nil {
+			break
+		}
+		if step.IsPlacement() {
+			step.Prerequisites = prerequisites
+			return *step, nil
+		}
+		b.apply(step)
+		prerequisites = append(prerequisites, *step)
+	}
+
+	// The candidates left by the prerequisites count, not just the numbers placed
+	pos, _, _ := b.mostConstrained()
+	return Step{
+		Technique:     GuessRequired,
+		Cell:          Cell{Row: pos.rowNumber, Col: pos.colNumber},
+		Digit:         solved[pos.rowNumber][pos.colNumber],
+		Prerequisites: prerequisites,
+	}, nil
+}
@@ -0,0 +1,148 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHint(t *testing.T) {
+	tt := []struct {
+		description string
+		input       [][]int
+		expectStep  Step
+		expectErr   error
+	}{
+		{
+			description: "naked single",
+			input: [][]int{
+				[]int{0, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 1, 2, 3},
+				[]int{7, 8, 9, 1, 2, 3, 4, 5, 6},
+				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
+				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
+			},
+			expectStep: Step{
+				Technique: NakedSingle,
+				Cell:      Cell{Row: 0, Col: 0},
+				Digit:     1,
+				Reasons: []Cell{
+					{Row: 0, Col: 1},
+					{Row: 0, Col: 2},
+					{Row: 0, Col: 3},
+					{Row: 0, Col: 4},
+					{Row: 0, Col: 5},
+					{Row: 0, Col: 6},
+					{Row: 0, Col: 7},
+					{Row: 0, Col: 8},
+				},
+			},
+		},
+		{
+			description: "guess required",
+			input: [][]int{
+				[]int{8, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 3, 6, 0, 0, 0, 0, 0},
+				[]int{0, 7, 0, 0, 9, 0, 2, 0, 0},
+				[]int{0, 5, 0, 0, 0, 7, 0, 0, 0},
+				[]int{0, 0, 0, 0, 4, 5, 7, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 0, 3, 0},
+				[]int{0, 0, 1, 0, 0, 0, 0, 6, 8},
+				[]int{0, 0, 8, 5, 0, 0, 0, 1, 0},
+				[]int{0, 9, 0, 0, 0, 0, 4, 0, 0},
+			},
+			expectStep: Step{
+				Technique: GuessRequired,
+				Cell:      Cell{Row: 7, Col: 6},
+				Digit:     9,
+			},
+		},
+		{
+			description: "complete",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 1, 2, 3},
+				[]int{7, 8, 9, 1, 2, 3, 4, 5, 6},
+				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
+				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
+			},
+			expectErr: ErrGridComplete,
+		},
+		{
+			description: "no solution",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 9},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			expectErr: ErrNoSolution,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			input := copyGrid(td.input)
+			step, err := Hint(input)
+			assert.Equal(t, td.expectErr, err)
+			assert.Equal(t, td.expectStep, step)
+			assert.Equal(t, td.input, input)
+		})
+	}
+}
+
+func TestHintPrerequisites(t *testing.T) {
+	// No number can be placed in this grid until candidates are removed by locked candidates
+	// and subsets
+	g, err := ParseGrid("017903600000080000900000507072010430000402070064370250701000065000030000005601720")
+	require.Nil(t, err)
+
+	step, err := Hint(g.Rows())
+	require.Nil(t, err)
+	assert.True(t, step.IsPlacement())
+	assert.NotEqual(t, GuessRequired, step.Technique)
+	require.NotEmpty(t, step.Prerequisites)
+	for _, p := range step.Prerequisites {
+		assert.False(t, p.IsPlacement())
+		assert.NotEmpty(t, p.Eliminations)
+	}
+}
+
+func TestHintGuessAfterPrerequisites(t *testing.T) {
+	// Without singles nothing can be placed, so the guess is made once the other techniques
+	// have removed every candidate they can
+	g, err := ParseGrid("017903600000080000900000507072010430000402070064370250701000065000030000005601720")
+	require.Nil(t, err)
+
+	step, err := Hint(g.Rows(), WithoutTechniques(NakedSingle, HiddenSingle))
+	require.Nil(t, err)
+	assert.Equal(t, GuessRequired, step.Technique)
+	require.NotEmpty(t, step.Prerequisites)
+
+	// The square guessed has the fewest candidates left, not the fewest numbers allowed by
+	// its row, column and box
+	b := newCandidateBoard(newGeometry(3, 3), g.Rows())
+	for i := range step.Prerequisites {
+		b.apply(&step.Prerequisites[i])
+	}
+	guessed := b.candidates(position{rowNumber: step.Cell.Row, colNumber: step.Cell.Col})
+	for _, pos := range getEmptySquares(b.grid) {
+		assert.True(t, guessed.count() <= b.candidates(pos).count())
+	}
+	assert.Equal(t, Cell{Row: 0, Col: 0}, step.Cell)
+	assert.Equal(t, 4, step.Digit)
+}
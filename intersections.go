@@ -0,0 +1,109 @@
+package soduku
+
+const (
+	// PointingCandidates removes a number from a row, column or other unit outside a box, when
+	// every square of the box that allows the number lies in that unit
+	PointingCandidates Technique = "pointing candidates"
+	// BoxLineReduction removes a number from the rest of a box, when every square of a row,
+	// column or other unit that allows the number lies in that box
+	BoxLineReduction Technique = "box/line reduction"
+)
+
+// findPointing returns the first pointing candidates, looking at the boxes in reading order
+func findPointing(b *CandidateBoard) *Step {
+	for regNum := range b.geo.regions {
+		if step := pointing(b, regNum); step != nil {
+			return step
+		}
+	}
+	return nil
+}
+
+// findBoxLineReduction returns the first box/line reduction in the rows, then columns, then
+// diagonals and extra units
+func findBoxLineReduction(b *CandidateBoard) *Step {
+	for _, h := range b.geo.lines() {
+		if step := boxLineReduction(b, h); step != nil {
+			return step
+		}
+	}
+	return nil
+}
+
+// pointing looks for a number whose candidates in the region all share a line, or another
+// unit that is not a region.
+// The number has to go in that line inside the region, so it is removed from the rest of the
+// line. It returns nil if nothing would be removed
+func pointing(b *CandidateBoard, regNum int) *Step {
+	h := b.geo.boxHouse(regNum)
+	for num := 1; num <= b.geo.size; num++ {
+		poss := positionsAllowing(b, h, num)
+		if len(poss) < 2 {
+			continue
+		}
+		for _, line := range b.geo.housesOf(poss[0]) {
+			if line.Kind == UnitBox || !allIn(poss, line) {
+				continue
+			}
+			elims := []Candidate{}
+			for _, pos := range line.positions {
+				if b.geo.regionNumber(pos.rowNumber, pos.colNumber) == regNum {
+					continue
+				}
+				elims = append(elims, candidatesOf(pos, b.candidates(pos)&(1<<uint(num)))...)
+			}
+			if len(elims) > 0 {
+				return &Step{Technique: PointingCandidates, Eliminations: elims, Reasons: cellsOf(poss)}
+			}
+		}
+	}
+	return nil
+}
+
+// boxLineReduction looks for a number whose candidates in the line all lie in one
+// region. The number has to go in that region on this line, so it is removed from the
+// region's squares off the line. It returns nil if nothing would be removed
+func boxLineReduction(b *CandidateBoard, h house) *Step {
+	for num := 1; num <= b.geo.size; num++ {
+		poss := positionsAllowing(b, h, num)
+		if len(poss) < 2 {
+			continue
+		}
+		regNum := b.geo.regionNumber(poss[0].rowNumber, poss[0].colNumber)
+		if !allIn(poss, b.geo.boxHouse(regNum)) {
+			continue
+		}
+
+		elims := []Candidate{}
+		for _, pos := range b.geo.regions[regNum] {
+			if !containsPosition(h.positions, pos) {
+				elims = append(elims, candidatesOf(pos, b.candidates(pos)&(1<<uint(num)))...)
+			}
+		}
+		if len(elims) > 0 {
+			return &Step{Technique: BoxLineReduction, Eliminations: elims, Reasons: cellsOf(poss)}
+		}
+	}
+	return nil
+}
+
+// positionsAllowing returns the squares of the house that still allow num
+func positionsAllowing(b *CandidateBoard, h house, num int) []position {
+	poss := []position{}
+	for _, pos := range h.positions {
+		if b.candidates(pos).has(num) {
+			poss = append(poss, pos)
+		}
+	}
+	return poss
+}
+
+// allIn returns whether every position is in the house
+func allIn(poss []position, h house) bool {
+	for _, pos := range poss {
+		if !containsPosition(h.positions, pos) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,206 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveGridIntersections(t *testing.T) {
+	tt := []struct {
+		description     string
+		input           string
+		expectTechnique Technique
+	}{
+		{
+			description:     "pointing candidates",
+			input:           "000000507000019008040300900600004000207108006000000800890040250000090000005200300",
+			expectTechnique: PointingCandidates,
+		},
+		{
+			description:     "box/line reduction",
+			input:           "020950000107000000805002007000300410001400902040000070000006000600008000010000053",
+			expectTechnique: BoxLineReduction,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+
+			log := &SolveLog{}
+			solved, _, err := SolveGrid(g.Rows(), WithSolveLog(log))
+			require.Nil(t, err)
+
+			techniques := replaySolveLog(t, g.Rows(), solved, log)
+			assert.True(t, techniques[td.expectTechnique] > 0)
+			assert.Equal(t, 0, techniques[Search])
+		})
+	}
+}
+
+func TestIntersections(t *testing.T) {
+	tt := []struct {
+		description string
+		input       [][]int
+		find        func(b *CandidateBoard) *Step
+		expectStep  *Step
+	}{
+		{
+			description: "empty grid has no pointing candidates",
+			input:       emptyTestGrid(),
+			find: func(b *CandidateBoard) *Step {
+				return pointing(b, 0)
+			},
+		},
+		{
+			// The first box is filled apart from its top row, so 1 is removed from the rest
+			// of the row
+			description: "pointing along a row",
+			input: [][]int{
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{2, 3, 4, 0, 0, 0, 0, 0, 0},
+				[]int{5, 6, 7, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			find: func(b *CandidateBoard) *Step {
+				return pointing(b, 0)
+			},
+			expectStep: &Step{
+				Technique: PointingCandidates,
+				Eliminations: []Candidate{
+					{Cell: Cell{Row: 0, Col: 3}, Digit: 1},
+					{Cell: Cell{Row: 0, Col: 4}, Digit: 1},
+					{Cell: Cell{Row: 0, Col: 5}, Digit: 1},
+					{Cell: Cell{Row: 0, Col: 6}, Digit: 1},
+					{Cell: Cell{Row: 0, Col: 7}, Digit: 1},
+					{Cell: Cell{Row: 0, Col: 8}, Digit: 1},
+				},
+				Reasons: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}, {Row: 0, Col: 2}},
+			},
+		},
+		{
+			// The first box is filled apart from its left column, so 1 is removed from the
+			// rest of the column
+			description: "pointing along a column",
+			input: [][]int{
+				[]int{0, 2, 5, 0, 0, 0, 0, 0, 0},
+				[]int{0, 3, 6, 0, 0, 0, 0, 0, 0},
+				[]int{0, 4, 7, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			find: func(b *CandidateBoard) *Step {
+				return pointing(b, 0)
+			},
+			expectStep: &Step{
+				Technique: PointingCandidates,
+				Eliminations: []Candidate{
+					{Cell: Cell{Row: 3, Col: 0}, Digit: 1},
+					{Cell: Cell{Row: 4, Col: 0}, Digit: 1},
+					{Cell: Cell{Row: 5, Col: 0}, Digit: 1},
+					{Cell: Cell{Row: 6, Col: 0}, Digit: 1},
+					{Cell: Cell{Row: 7, Col: 0}, Digit: 1},
+					{Cell: Cell{Row: 8, Col: 0}, Digit: 1},
+				},
+				Reasons: []Cell{{Row: 0, Col: 0}, {Row: 1, Col: 0}, {Row: 2, Col: 0}},
+			},
+		},
+		{
+			description: "empty grid has no box/line reduction",
+			input:       emptyTestGrid(),
+			find: func(b *CandidateBoard) *Step {
+				return boxLineReduction(b, b.geo.houses[0])
+			},
+		},
+		{
+			// The top row is filled outside the first box, so 1 has to go in the first box on
+			// that row and is removed from the box's other rows
+			description: "box/line reduction on a row",
+			input: [][]int{
+				[]int{0, 0, 0, 2, 3, 4, 5, 6, 7},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			find: func(b *CandidateBoard) *Step {
+				return boxLineReduction(b, b.geo.houses[0])
+			},
+			expectStep: &Step{
+				Technique: BoxLineReduction,
+				Eliminations: []Candidate{
+					{Cell: Cell{Row: 1, Col: 0}, Digit: 1},
+					{Cell: Cell{Row: 1, Col: 1}, Digit: 1},
+					{Cell: Cell{Row: 1, Col: 2}, Digit: 1},
+					{Cell: Cell{Row: 2, Col: 0}, Digit: 1},
+					{Cell: Cell{Row: 2, Col: 1}, Digit: 1},
+					{Cell: Cell{Row: 2, Col: 2}, Digit: 1},
+				},
+				Reasons: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}, {Row: 0, Col: 2}},
+			},
+		},
+		{
+			// The last column is filled outside the bottom right box, so 1 is removed from the
+			// box's other columns
+			description: "box/line reduction on a column",
+			input: [][]int{
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 2},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 3},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 4},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 5},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 6},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 7},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			find: func(b *CandidateBoard) *Step {
+				return boxLineReduction(b, b.geo.houses[17])
+			},
+			expectStep: &Step{
+				Technique: BoxLineReduction,
+				Eliminations: []Candidate{
+					{Cell: Cell{Row: 6, Col: 6}, Digit: 1},
+					{Cell: Cell{Row: 6, Col: 7}, Digit: 1},
+					{Cell: Cell{Row: 7, Col: 6}, Digit: 1},
+					{Cell: Cell{Row: 7, Col: 7}, Digit: 1},
+					{Cell: Cell{Row: 8, Col: 6}, Digit: 1},
+					{Cell: Cell{Row: 8, Col: 7}, Digit: 1},
+				},
+				Reasons: []Cell{{Row: 6, Col: 8}, {Row: 7, Col: 8}, {Row: 8, Col: 8}},
+			},
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			b := newCandidateBoard(newGeometry(3, 3), copyGrid(td.input))
+			assert.Equal(t, td.expectStep, td.find(b))
+		})
+	}
+}
+
+// emptyTestGrid returns a grid with every square empty
+func emptyTestGrid() [][]int {
+	grid := make([][]int, 9)
+	for i := range grid {
+		grid[i] = make([]int, 9)
+	}
+	return grid
+}
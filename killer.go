@@ -0,0 +1,246 @@
+package soduku
+
+import (
+	"fmt"
+)
+
+// CageSum removes numbers from the squares of a killer cage when they are in no combination of
+// different numbers that adds up to the cage's sum, given what the squares still allow
+const CageSum Technique = "cage sum"
+
+// Cage is a group of squares of a killer grid, whose numbers must be different and add up to
+// Sum
+type Cage struct {
+	Cells []Cell `json:"cells"`
+	Sum   int    `json:"sum"`
+}
+
+// CageError is returned when a cage given by WithCages cannot be filled
+type CageError struct {
+	// Index is the position of the cage among the cages
+	Index int
+	// Cell is the square that is off the grid or already in a cage, or nil if no numbers fit
+	// the cage
+	Cell *Cell
+	// Length is the number of squares in the cage
+	Length int
+	Sum    int
+}
+
+func (e *CageError) Error() string {
+	if e.Cell != nil {
+		return fmt.Sprintf("unexpected square %s in cage %d", e.Cell, e.Index)
+	}
+	return fmt.Sprintf("no %d different numbers add up to %d in cage %d", e.Length, e.Sum, e.Index)
+}
+
+// CageConflict is a cage that breaks the rules of a killer grid. Digit and Cells are set when
+// a number appears more than once in the cage, otherwise the numbers in the cage cannot add up
+// to its sum. Total is the sum of the numbers in the cage
+type CageConflict struct {
+	Cage  int    `json:"cage"`
+	Sum   int    `json:"sum"`
+	Total int    `json:"total"`
+	Digit int    `json:"digit,omitempty"`
+	Cells []Cell `json:"cells,omitempty"`
+}
+
+// cage is a cage of the geometry, with every set of numbers that could fill it
+type cage struct {
+	positions []position
+	sum       int
+	combos    []digitSet
+}
+
+// allowed returns the numbers that can still go in the empty squares of the cage, when used
+// are the numbers already in it
+func (c cage) allowed(used digitSet) digitSet {
+	allowed := digitSet(0)
+	for _, combo := range c.combos {
+		if combo&used == used {
+			allowed |= combo
+		}
+	}
+	return allowed &^ used
+}
+
+// fits returns whether the numbers in used are part of one of the cage's combinations
+func (c cage) fits(used digitSet) bool {
+	for _, combo := range c.combos {
+		if combo&used == used {
+			return true
+		}
+	}
+	return false
+}
+
+// sumCombinations returns every set of size different numbers from 1 to n that adds up to sum
+func sumCombinations(n, size, sum int) []digitSet {
+	combos := []digitSet{}
+	var pick func(from, left, remaining int, set digitSet)
+	pick = func(from, left, remaining int, set digitSet) {
+		if left == 0 {
+			if remaining == 0 {
+				combos = append(combos, set)
+			}
+			return
+		}
+		// Stop once the smallest numbers left would overshoot the sum
+		for num := from; num <= n && num*left+left*(left-1)/2 <= remaining; num++ {
+			pick(num+1, left-1, remaining-num, set|1<<uint(num))
+		}
+	}
+	pick(1, size, sum, 0)
+	return combos
+}
+
+// addCages adds the cages to the geometry. The combinations are worked out once for each size
+// and sum, and shared by the cages that have them
+func (g *geometry) addCages(cages []Cage) error {
+	combos := map[[2]int][]digitSet{}
+	for i, c := range cages {
+		added := cage{sum: c.Sum}
+		for j, cell := range c.Cells {
+			if !g.onGrid(cell) || g.cageOf[cell.Row][cell.Col] >= 0 {
+				return &CageError{Index: i, Cell: &c.Cells[j], Length: len(c.Cells), Sum: c.Sum}
+			}
+			g.cageOf[cell.Row][cell.Col] = i
+			added.positions = append(added.positions, position{rowNumber: cell.Row, colNumber: cell.Col})
+		}
+
+		key := [2]int{len(c.Cells), c.Sum}
+		if _, ok := combos[key]; !ok {
+			combos[key] = sumCombinations(g.size, len(c.Cells), c.Sum)
+		}
+		added.combos = combos[key]
+		if len(added.combos) == 0 {
+			return &CageError{Index: i, Length: len(c.Cells), Sum: c.Sum}
+		}
+		g.cages = append(g.cages, added)
+	}
+	return nil
+}
+
+// addCageConflicts records every cage holding a number twice, or numbers that cannot add up
+// to its sum
+func (cg *CheckedGrid) addCageConflicts(geo *geometry, grid [][]int) {
+	for i, c := range geo.cages {
+		found := make([][]Cell, geo.size+1)
+		used, total, full := digitSet(0), 0, true
+		for _, pos := range c.positions {
+			num := grid[pos.rowNumber][pos.colNumber]
+			if num == 0 {
+				full = false
+				continue
+			}
+			found[num] = append(found[num], Cell{Row: pos.rowNumber, Col: pos.colNumber})
+			used |= 1 << uint(num)
+			total += num
+		}
+
+		broken := false
+		for num, cells := range found {
+			if len(cells) <= 1 {
+				continue
+			}
+			broken = true
+			cg.Message = fmt.Sprintf("%s A duplicate of %d was found in cage %d\n", cg.Message, num, i)
+			cg.CageConflicts = append(cg.CageConflicts, CageConflict{Cage: i, Sum: c.sum, Total: total, Digit: num, Cells: cells})
+		}
+		if !broken && !c.fits(used) {
+			broken = true
+			if full {
+				cg.Message = fmt.Sprintf("%s The squares of cage %d add up to %d instead of %d\n", cg.Message, i, total, c.sum)
+			} else {
+				cg.Message = fmt.Sprintf("%s The squares of cage %d cannot add up to %d\n", cg.Message, i, c.sum)
+			}
+			cg.CageConflicts = append(cg.CageConflicts, CageConflict{Cage: i, Sum: c.sum, Total: total})
+		}
+		if broken {
+			cg.Complete = false
+			cg.Valid = false
+		}
+	}
+}
+
+// findCageSum returns the first cage, in the order given, where a square allows a number that
+// is in none of the combinations the cage's squares can still make
+func findCageSum(b *CandidateBoard) *Step {
+	for _, c := range b.geo.cages {
+		if step := cageSum(b, c); step != nil {
+			return step
+		}
+	}
+	return nil
+}
+
+// cageSum removes the candidates of the cage's empty squares that are in no way of filling
+// them with one of the cage's combinations. A combination has to hold the numbers already
+// placed in the cage, and the rest of its numbers have to go one to each empty square. It
+// returns nil if nothing would be removed
+func cageSum(b *CandidateBoard, c cage) *Step {
+	placed := digitSet(0)
+	empty := []position{}
+	for _, pos := range c.positions {
+		if num := b.grid[pos.rowNumber][pos.colNumber]; num != 0 {
+			placed |= 1 << uint(num)
+		} else {
+			empty = append(empty, pos)
+		}
+	}
+	// A number placed twice leaves the cage broken, there is nothing to remove
+	if len(empty) == 0 || placed.count() != len(c.positions)-len(empty) {
+		return nil
+	}
+
+	cands := make([]digitSet, len(empty))
+	for i, pos := range empty {
+		cands[i] = b.candidates(pos)
+	}
+	fits := make([]digitSet, len(empty))
+	for _, combo := range c.combos {
+		if combo&placed != placed {
+			continue
+		}
+		rest := combo &^ placed
+		for i := range empty {
+			others := append(append([]digitSet{}, cands[:i]...), cands[i+1:]...)
+			for _, num := range (cands[i] & rest &^ fits[i]).numbers() {
+				if canFill(others, rest&^(1<<uint(num)), map[digitSet]bool{}) {
+					fits[i] |= 1 << uint(num)
+				}
+			}
+		}
+	}
+
+	elims := []Candidate{}
+	for i, pos := range empty {
+		elims = append(elims, candidatesOf(pos, cands[i]&^fits[i])...)
+	}
+	if len(elims) == 0 {
+		return nil
+	}
+	return &Step{Technique: CageSum, Eliminations: elims, Reasons: cellsOf(c.positions)}
+}
+
+// canFill returns whether the numbers can go one to each square, where cands holds what each
+// square allows. There must be as many numbers as squares. The squares are filled in order,
+// so memo records the result for the numbers left once the earlier squares are filled
+func canFill(cands []digitSet, nums digitSet, memo map[digitSet]bool) bool {
+	i := len(cands) - nums.count()
+	if i == len(cands) {
+		return true
+	}
+	if ok, seen := memo[nums]; seen {
+		return ok
+	}
+	ok := false
+	for _, num := range (cands[i] & nums).numbers() {
+		if canFill(cands, nums&^(1<<uint(num)), memo) {
+			ok = true
+			break
+		}
+	}
+	memo[nums] = ok
+	return ok
+}
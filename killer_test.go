@@ -0,0 +1,304 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCages are the cages of a killer puzzle with no numbers given
+var testCages = []Cage{
+	{Cells: []Cell{{Row: 2, Col: 2}, {Row: 3, Col: 2}, {Row: 3, Col: 3}}, Sum: 11},
+	{Cells: []Cell{{Row: 6, Col: 0}, {Row: 6, Col: 1}, {Row: 6, Col: 2}, {Row: 6, Col: 3}, {Row: 7, Col: 0}, {Row: 7, Col: 1}}, Sum: 28},
+	{Cells: []Cell{{Row: 0, Col: 1}, {Row: 1, Col: 0}, {Row: 1, Col: 1}}, Sum: 13},
+	{Cells: []Cell{{Row: 4, Col: 1}, {Row: 5, Col: 1}, {Row: 5, Col: 2}}, Sum: 22},
+	{Cells: []Cell{{Row: 3, Col: 7}, {Row: 3, Col: 8}, {Row: 4, Col: 6}, {Row: 4, Col: 7}, {Row: 4, Col: 8}, {Row: 5, Col: 7}}, Sum: 27},
+	{Cells: []Cell{{Row: 0, Col: 4}, {Row: 1, Col: 3}, {Row: 1, Col: 4}, {Row: 1, Col: 5}, {Row: 2, Col: 4}}, Sum: 27},
+	{Cells: []Cell{{Row: 4, Col: 4}, {Row: 5, Col: 4}, {Row: 6, Col: 4}, {Row: 6, Col: 5}, {Row: 7, Col: 4}, {Row: 7, Col: 5}}, Sum: 27},
+	{Cells: []Cell{{Row: 1, Col: 6}, {Row: 2, Col: 6}, {Row: 2, Col: 7}, {Row: 3, Col: 5}, {Row: 3, Col: 6}}, Sum: 29},
+	{Cells: []Cell{{Row: 7, Col: 8}, {Row: 8, Col: 6}, {Row: 8, Col: 7}, {Row: 8, Col: 8}}, Sum: 15},
+	{Cells: []Cell{{Row: 0, Col: 6}, {Row: 0, Col: 7}, {Row: 1, Col: 7}}, Sum: 18},
+	{Cells: []Cell{{Row: 4, Col: 5}, {Row: 5, Col: 5}, {Row: 5, Col: 6}}, Sum: 17},
+	{Cells: []Cell{{Row: 0, Col: 2}, {Row: 0, Col: 3}, {Row: 1, Col: 2}}, Sum: 23},
+	{Cells: []Cell{{Row: 0, Col: 8}, {Row: 1, Col: 8}, {Row: 2, Col: 8}}, Sum: 14},
+	{Cells: []Cell{{Row: 3, Col: 4}}, Sum: 5},
+	{Cells: []Cell{{Row: 4, Col: 2}, {Row: 4, Col: 3}, {Row: 5, Col: 3}}, Sum: 19},
+	{Cells: []Cell{{Row: 7, Col: 2}, {Row: 8, Col: 1}, {Row: 8, Col: 2}, {Row: 8, Col: 3}}, Sum: 26},
+	{Cells: []Cell{{Row: 2, Col: 0}, {Row: 3, Col: 0}, {Row: 4, Col: 0}, {Row: 5, Col: 0}}, Sum: 18},
+	{Cells: []Cell{{Row: 6, Col: 6}, {Row: 6, Col: 7}, {Row: 7, Col: 6}}, Sum: 22},
+	{Cells: []Cell{{Row: 7, Col: 3}}, Sum: 1},
+	{Cells: []Cell{{Row: 8, Col: 4}, {Row: 8, Col: 5}}, Sum: 8},
+	{Cells: []Cell{{Row: 0, Col: 0}}, Sum: 2},
+	{Cells: []Cell{{Row: 5, Col: 8}, {Row: 6, Col: 8}}, Sum: 11},
+	{Cells: []Cell{{Row: 0, Col: 5}}, Sum: 1},
+	{Cells: []Cell{{Row: 2, Col: 5}}, Sum: 7},
+	{Cells: []Cell{{Row: 2, Col: 3}}, Sum: 2},
+	{Cells: []Cell{{Row: 8, Col: 0}}, Sum: 3},
+	{Cells: []Cell{{Row: 7, Col: 7}}, Sum: 2},
+	{Cells: []Cell{{Row: 2, Col: 1}, {Row: 3, Col: 1}}, Sum: 7},
+}
+
+// testCagesSolution is the only solution of testCages
+const testCagesSolution = "256841379719536284843297561432659718568713492197428635921374856674185923385962147"
+
+func TestSumCombinations(t *testing.T) {
+	tt := []struct {
+		description   string
+		n, size, sum  int
+		expectNumbers [][]int
+	}{
+		{description: "smallest pair", n: 9, size: 2, sum: 3, expectNumbers: [][]int{{1, 2}}},
+		{description: "largest pair", n: 9, size: 2, sum: 17, expectNumbers: [][]int{{8, 9}}},
+		{description: "two ways", n: 9, size: 3, sum: 8, expectNumbers: [][]int{{1, 2, 5}, {1, 3, 4}}},
+		{description: "every number", n: 9, size: 9, sum: 45, expectNumbers: [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9}}},
+		{description: "larger grid", n: 16, size: 2, sum: 31, expectNumbers: [][]int{{15, 16}}},
+		{description: "too large", n: 9, size: 2, sum: 18, expectNumbers: [][]int{}},
+		{description: "too small", n: 9, size: 3, sum: 5, expectNumbers: [][]int{}},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			nums := [][]int{}
+			for _, combo := range sumCombinations(td.n, td.size, td.sum) {
+				nums = append(nums, combo.numbers())
+			}
+			assert.Equal(t, td.expectNumbers, nums)
+		})
+	}
+	assert.Len(t, sumCombinations(9, 3, 15), 8)
+}
+
+func TestSolveGridKiller(t *testing.T) {
+	expect, err := ParseGrid(testCagesSolution)
+	require.Nil(t, err)
+
+	n, err := CountSolutions(emptyTestGrid(), 2, WithCages(testCages...))
+	require.Nil(t, err)
+	assert.Equal(t, 1, n)
+
+	log := &SolveLog{}
+	solved, cg, err := SolveGrid(emptyTestGrid(), WithCages(testCages...), WithSolveLog(log))
+	require.Nil(t, err)
+	assert.True(t, cg.Unique)
+	assert.Equal(t, expect.Rows(), solved)
+	techniques := replaySolveLog(t, emptyTestGrid(), solved, log)
+	assert.True(t, techniques[CageSum] > 0)
+	assert.Equal(t, 0, techniques[Search])
+
+	solved, _, err = SolveGrid(emptyTestGrid(), WithCages(testCages...), WithEngine(EngineDLX))
+	require.Nil(t, err)
+	assert.Equal(t, expect.Rows(), solved)
+}
+
+func TestRateKiller(t *testing.T) {
+	// The killer needs no search, so both ratings are given
+	r, err := Rate(emptyTestGrid(), WithCages(testCages...))
+	require.Nil(t, err)
+	assert.Equal(t, TierMedium, r.Tier)
+
+	rating, err := ExplainerRating(emptyTestGrid(), WithCages(testCages...))
+	require.Nil(t, err)
+	assert.Equal(t, 3.0, rating)
+}
+
+func TestSolveGridKillerSearch(t *testing.T) {
+	g, err := ParseGrid(testCagesSolution)
+	require.Nil(t, err)
+	expect := g.Rows()
+
+	// Cages along the rows and the diagonals given leave more than one solution
+	cages := []Cage{}
+	grid := emptyTestGrid()
+	for row := 0; row < 9; row++ {
+		col := 0
+		for _, size := range []int{2, 3, 4} {
+			c := Cage{}
+			for ; len(c.Cells) < size; col++ {
+				c.Cells = append(c.Cells, Cell{Row: row, Col: col})
+				c.Sum += expect[row][col]
+			}
+			cages = append(cages, c)
+		}
+		grid[row][row] = expect[row][row]
+		grid[row][8-row] = expect[row][8-row]
+	}
+
+	log := &SolveLog{}
+	solved, cg, err := SolveGrid(grid, WithCages(cages...), WithSolveLog(log))
+	require.Nil(t, err)
+	assert.True(t, cg.Valid)
+	assert.True(t, cg.Complete)
+	assert.False(t, cg.Unique)
+	assert.True(t, CheckGrid(solved, WithCages(cages...)).Complete)
+	techniques := replaySolveLog(t, grid, solved, log)
+	assert.True(t, techniques[Search] > 0)
+}
+
+func TestSolveGridKillerInvalid(t *testing.T) {
+	// The cage holds 1 twice, though no row, column or box does
+	grid := emptyTestGrid()
+	grid[0][0], grid[1][3] = 1, 1
+	cage := Cage{Cells: []Cell{{Row: 0, Col: 0}, {Row: 1, Col: 3}, {Row: 2, Col: 6}}, Sum: 6}
+
+	for _, engine := range []Engine{EngineLogical, EngineDLX} {
+		_, cg, err := SolveGrid(grid, WithCages(cage), WithEngine(engine))
+		assert.EqualError(t, err, "the grid is invalid")
+		assert.False(t, cg.Valid)
+		assert.Equal(t, []CageConflict{
+			{Cage: 0, Sum: 6, Total: 2, Digit: 1, Cells: []Cell{{Row: 0, Col: 0}, {Row: 1, Col: 3}}},
+		}, cg.CageConflicts)
+	}
+
+	_, err := Rate(grid, WithCages(cage))
+	assert.EqualError(t, err, "the grid is invalid")
+
+	n, err := CountSolutions(grid, 2, WithCages(cage))
+	require.Nil(t, err)
+	assert.Equal(t, 0, n)
+
+	// A cage that can no longer reach its sum has no solutions either
+	grid = emptyTestGrid()
+	grid[0][0] = 9
+	n, err = CountSolutions(grid, 2, WithCages(cage))
+	require.Nil(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestCheckGridKiller(t *testing.T) {
+	solution, err := ParseGrid(testCagesSolution)
+	require.Nil(t, err)
+	assert.Equal(t, CheckedGrid{Valid: true, Complete: true}, solution.Check(WithCages(testCages...)))
+
+	cages := []Cage{
+		{Cells: []Cell{{Row: 0, Col: 0}, {Row: 1, Col: 1}}, Sum: 5},
+		{Cells: []Cell{{Row: 0, Col: 4}, {Row: 2, Col: 6}, {Row: 3, Col: 3}}, Sum: 10},
+		{Cells: []Cell{{Row: 4, Col: 4}, {Row: 4, Col: 5}}, Sum: 4},
+		{Cells: []Cell{{Row: 8, Col: 8}, {Row: 8, Col: 7}}, Sum: 17},
+	}
+	grid := emptyTestGrid()
+	grid[0][0], grid[1][1] = 1, 3
+	grid[0][4], grid[2][6] = 4, 4
+	grid[4][4] = 2
+	grid[8][8] = 7
+
+	cg := CheckGrid(grid, WithCages(cages...))
+	assert.False(t, cg.Valid)
+	assert.False(t, cg.Complete)
+	assert.Empty(t, cg.Conflicts)
+	assert.Equal(t, []CageConflict{
+		{Cage: 0, Sum: 5, Total: 4},
+		{Cage: 1, Sum: 10, Total: 8, Digit: 4, Cells: []Cell{{Row: 0, Col: 4}, {Row: 2, Col: 6}}},
+		{Cage: 2, Sum: 4, Total: 2},
+		{Cage: 3, Sum: 17, Total: 7},
+	}, cg.CageConflicts)
+	assert.Equal(t, " The squares of cage 0 add up to 4 instead of 5\n"+
+		" A duplicate of 4 was found in cage 1\n"+
+		" The squares of cage 2 cannot add up to 4\n"+
+		" The squares of cage 3 cannot add up to 17\n", cg.Message)
+}
+
+func TestCageErrors(t *testing.T) {
+	tt := []struct {
+		description string
+		cages       []Cage
+		expectErr   error
+	}{
+		{
+			description: "square off the grid",
+			cages:       []Cage{{Cells: []Cell{{Row: 0, Col: 8}, {Row: 0, Col: 9}}, Sum: 10}},
+			expectErr:   &CageError{Index: 0, Cell: &Cell{Row: 0, Col: 9}, Length: 2, Sum: 10},
+		},
+		{
+			description: "square in two cages",
+			cages: []Cage{
+				{Cells: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}}, Sum: 10},
+				{Cells: []Cell{{Row: 1, Col: 1}, {Row: 0, Col: 1}}, Sum: 10},
+			},
+			expectErr: &CageError{Index: 1, Cell: &Cell{Row: 0, Col: 1}, Length: 2, Sum: 10},
+		},
+		{
+			description: "sum too large",
+			cages:       []Cage{{Cells: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}}, Sum: 18}},
+			expectErr:   &CageError{Index: 0, Length: 2, Sum: 18},
+		},
+		{
+			description: "empty cage",
+			cages:       []Cage{{Sum: 3}},
+			expectErr:   &CageError{Index: 0, Length: 0, Sum: 3},
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			_, _, err := SolveGrid(emptyTestGrid(), WithCages(td.cages...))
+			assert.Equal(t, td.expectErr, err)
+
+			cg := CheckGrid(emptyTestGrid(), WithCages(td.cages...))
+			assert.False(t, cg.Valid)
+			assert.Equal(t, td.expectErr.Error(), cg.Message)
+		})
+	}
+}
+
+func TestNewSquareKiller(t *testing.T) {
+	grid := emptyTestGrid()
+	cages := WithCages(
+		Cage{Cells: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}}, Sum: 3},
+		Cage{Cells: []Cell{{Row: 4, Col: 4}, {Row: 4, Col: 5}, {Row: 5, Col: 5}}, Sum: 10},
+	)
+
+	s, err := NewSquare(grid, position{rowNumber: 0, colNumber: 0}, cages)
+	require.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, s.possibleNums)
+
+	// With 6 placed the rest of the cage holds 1 and 3
+	grid[5][5] = 6
+	s, err = NewSquare(grid, position{rowNumber: 4, colNumber: 4}, cages)
+	require.Nil(t, err)
+	assert.Equal(t, []int{1, 3}, s.possibleNums)
+}
+
+func TestCageSum(t *testing.T) {
+	// The cage adds up to 10 from 1 2 7, 1 3 6, 1 4 5 or 2 3 5. Once the first two squares
+	// only allow 1 and 2, the third has to hold 7
+	geo := newGeometry(3, 3)
+	require.Nil(t, geo.addCages([]Cage{{Cells: []Cell{{Row: 0, Col: 0}, {Row: 3, Col: 3}, {Row: 6, Col: 6}}, Sum: 10}}))
+	b := newCandidateBoard(geo, emptyTestGrid())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, geo.cages[0].allowed(0).numbers())
+	b.cands[0][0] = 1<<1 | 1<<2
+	b.cands[3][3] = 1<<1 | 1<<2
+	b.cands[6][6] = geo.cages[0].allowed(0)
+
+	step := findCageSum(b)
+	require.NotNil(t, step)
+	assert.Equal(t, CageSum, step.Technique)
+	assert.Equal(t, []Candidate{
+		{Cell: Cell{Row: 6, Col: 6}, Digit: 1},
+		{Cell: Cell{Row: 6, Col: 6}, Digit: 2},
+		{Cell: Cell{Row: 6, Col: 6}, Digit: 3},
+		{Cell: Cell{Row: 6, Col: 6}, Digit: 4},
+		{Cell: Cell{Row: 6, Col: 6}, Digit: 5},
+		{Cell: Cell{Row: 6, Col: 6}, Digit: 6},
+	}, step.Eliminations)
+	assert.Equal(t, []Cell{{Row: 0, Col: 0}, {Row: 3, Col: 3}, {Row: 6, Col: 6}}, step.Reasons)
+
+	b.apply(step)
+	assert.Nil(t, findCageSum(b))
+
+	// A cage holding a number twice is broken, it is left alone
+	grid := emptyTestGrid()
+	grid[0][0], grid[3][3] = 1, 1
+	b = newCandidateBoard(geo, grid)
+	assert.Nil(t, findCageSum(b))
+}
+
+func TestCanFill(t *testing.T) {
+	cands := []digitSet{1 << 1, 1<<1 | 1<<2, 1<<1 | 1<<2 | 1<<3}
+	assert.True(t, canFill(cands, 1<<1|1<<2|1<<3, map[digitSet]bool{}))
+	assert.False(t, canFill(cands, 1<<1|1<<2|1<<4, map[digitSet]bool{}))
+
+	// The first two squares both need 1
+	cands[1] = 1 << 1
+	assert.False(t, canFill(cands, 1<<1|1<<2|1<<3, map[digitSet]bool{}))
+}
@@ -0,0 +1,89 @@
+package soduku
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// Layout is the set of regions of a jigsaw grid. The regions take the place of the boxes and
+// can be any shape, as long as each has one square for every row of the grid. Layouts are
+// built with ParseLayout and given to the solver with WithLayout
+type Layout struct {
+	size    int
+	regions []region
+}
+
+// LayoutError is returned by ParseLayout when a region does not have one square for every
+// row of the grid, or when the layout has no squares or too many for the largest grid
+type LayoutError struct {
+	// Region is the letter of the region, or 0 if the error is in the number of squares of
+	// the whole layout
+	Region rune
+	// Length is the number of squares in the region or layout
+	Length int
+	// Size is the number of squares expected, the most there can be for the whole layout
+	Size int
+}
+
+func (e *LayoutError) Error() string {
+	if e.Region == 0 {
+		return fmt.Sprintf("expected 1 to %d squares in the layout, found %d", e.Size, e.Length)
+	}
+	return fmt.Sprintf("expected %d squares in region %q, found %d", e.Size, e.Region, e.Length)
+}
+
+// ParseLayout reads a jigsaw layout from a string with one letter per square in reading
+// order, the squares sharing a letter form a region. Letters and digits can both be used, and
+// regions are numbered in the order their letter first appears. Whitespace is ignored, so the
+// layout can be written on one line or as one line per row
+func ParseLayout(s string) (Layout, error) {
+	letters := []rune{}
+	for offset, c := range s {
+		switch {
+		case unicode.IsSpace(c):
+			continue
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			letters = append(letters, c)
+		default:
+			return Layout{}, &SyntaxError{Offset: offset, Char: c}
+		}
+	}
+
+	// The rows are as long as the smallest grid that holds every square
+	size := 0
+	for size*size < len(letters) {
+		size++
+	}
+	if len(letters) == 0 || size > maxSize {
+		return Layout{}, &LayoutError{Length: len(letters), Size: maxSize * maxSize}
+	}
+	if size*size != len(letters) {
+		last := (len(letters) - 1) / size
+		return Layout{}, &ShapeError{Row: last, Length: len(letters) - last*size, Size: size}
+	}
+
+	l := Layout{size: size}
+	index := map[rune]int{}
+	names := []rune{}
+	for i, c := range letters {
+		regNum, ok := index[c]
+		if !ok {
+			regNum = len(l.regions)
+			index[c] = regNum
+			names = append(names, c)
+			l.regions = append(l.regions, region{})
+		}
+		l.regions[regNum] = append(l.regions[regNum], position{rowNumber: i / size, colNumber: i % size})
+	}
+	for regNum, reg := range l.regions {
+		if len(reg) != size {
+			return Layout{}, &LayoutError{Region: names[regNum], Length: len(reg), Size: size}
+		}
+	}
+	return l, nil
+}
+
+// Size returns the number of rows and columns of the grids the layout is for
+func (l Layout) Size() int {
+	return l.size
+}
@@ -0,0 +1,161 @@
+package soduku
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLayout(t *testing.T) {
+	tt := []struct {
+		description   string
+		input         string
+		expectSize    int
+		expectRegions []region
+		expectErr     error
+	}{
+		{
+			description: "4x4",
+			input:       "AABB ACCB ACDB CDDD",
+			expectSize:  4,
+			expectRegions: []region{
+				{{0, 0}, {0, 1}, {1, 0}, {2, 0}},
+				{{0, 2}, {0, 3}, {1, 3}, {2, 3}},
+				{{1, 1}, {1, 2}, {2, 1}, {3, 0}},
+				{{2, 2}, {3, 1}, {3, 2}, {3, 3}},
+			},
+		},
+		{
+			description: "digits",
+			input:       "1122 1332 1342 3444",
+			expectSize:  4,
+			expectRegions: []region{
+				{{0, 0}, {0, 1}, {1, 0}, {2, 0}},
+				{{0, 2}, {0, 3}, {1, 3}, {2, 3}},
+				{{1, 1}, {1, 2}, {2, 1}, {3, 0}},
+				{{2, 2}, {3, 1}, {3, 2}, {3, 3}},
+			},
+		},
+		{
+			description: "unexpected character",
+			input:       "AAB.",
+			expectErr:   &SyntaxError{Offset: 3, Char: '.'},
+		},
+		{
+			description: "short row",
+			input:       "AABB ACCB ACDB CDD",
+			expectErr:   &ShapeError{Row: 3, Length: 3, Size: 4},
+		},
+		{
+			description: "region too big",
+			input:       "AABB ACCB ACDB ADDD",
+			expectErr:   &LayoutError{Region: 'A', Length: 5, Size: 4},
+		},
+		{
+			description: "empty",
+			expectErr:   &LayoutError{Length: 0, Size: 625},
+		},
+		{
+			// 26 rows, one more than the largest grid
+			description: "too big",
+			input:       strings.Repeat("A", 626),
+			expectErr:   &LayoutError{Length: 626, Size: 625},
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			l, err := ParseLayout(td.input)
+			assert.Equal(t, td.expectErr, err)
+			if td.expectErr == nil {
+				assert.Equal(t, td.expectSize, l.Size())
+				assert.Equal(t, td.expectRegions, l.regions)
+			}
+		})
+	}
+}
+
+func TestSolveGridJigsaw(t *testing.T) {
+	tt := []struct {
+		description     string
+		layout          string
+		input           string
+		expectOutput    string
+		expectTechnique Technique
+	}{
+		{
+			// No boxes fit a 5x5 grid, so only a layout can give it regions
+			description:     "5x5",
+			layout:          "AAABB ACCBB ACCDB EECDD EEEDD",
+			input:           ".23.5 5.41. .1.53 2.5.1 35.2.",
+			expectOutput:    "12345 53412 41253 24531 35124",
+			expectTechnique: NakedSingle,
+		},
+		{
+			description:     "6x6",
+			layout:          "AAAABB CABBBD CABDDD CCCCDD EEEEEF EFFFFF",
+			input:           ".34... ...1.. ...6.. ....23 ...... ......",
+			expectOutput:    "134562 263145 325614 516423 451236 642351",
+			expectTechnique: PointingCandidates,
+		},
+		{
+			description: "9x9",
+			layout: `
+				AAAABCCCC AABBBBCCC AADBBBBCC DADEEEFFF DDDEEEFFF
+				DGDEEEFIF DGHHHHFII GGGHHIIII GGGGHHHII
+			`,
+			input:           ".....6.94.2...........3.........8..2............3.4..1.8..6..1..358.......9.....3",
+			expectOutput:    "513786294627945138894132675341578962258619347976324851782463519135897426469251783",
+			expectTechnique: PointingCandidates,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			l, err := ParseLayout(td.layout)
+			require.Nil(t, err)
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+			expect, err := ParseGrid(td.expectOutput)
+			require.Nil(t, err)
+
+			log := &SolveLog{}
+			solved, cg, err := SolveGrid(g.Rows(), WithLayout(l), WithSolveLog(log))
+			require.Nil(t, err)
+			assert.Equal(t, CheckedGrid{Valid: true, Complete: true, Unique: true}, cg)
+			assert.Equal(t, expect.Rows(), solved)
+
+			techniques := replaySolveLog(t, g.Rows(), solved, log)
+			assert.True(t, techniques[td.expectTechnique] > 0)
+			assert.Equal(t, 0, techniques[Search])
+
+			solved, _, err = SolveGrid(g.Rows(), WithLayout(l), WithEngine(EngineDLX))
+			require.Nil(t, err)
+			assert.Equal(t, expect.Rows(), solved)
+		})
+	}
+}
+
+func TestCheckGridJigsaw(t *testing.T) {
+	l, err := ParseLayout("AAAABB CABBBD CABDDD CCCCDD EEEEEF EFFFFF")
+	require.Nil(t, err)
+
+	// Valid with the classic 2x3 boxes, but region B holds 5 twice
+	grid, err := ParseGrid("....5. ..5... ...... ...... ...... ......")
+	require.Nil(t, err)
+	assert.True(t, grid.Check().Valid)
+
+	cg := grid.Check(WithLayout(l))
+	assert.False(t, cg.Valid)
+	assert.Equal(t, []Conflict{
+		{Unit: Unit{Kind: UnitBox, Index: 1}, Digit: 5, Cells: []Cell{{Row: 0, Col: 4}, {Row: 1, Col: 2}}},
+	}, cg.Conflicts)
+	assert.Equal(t, " A duplicate of 5 was found in region 1\n", cg.Message)
+
+	// The layout has to match the size of the grid
+	cg = CheckGrid(emptyTestGrid(), WithLayout(l))
+	assert.False(t, cg.Valid)
+	assert.Equal(t, "expected 6 rows, found 9", cg.Message)
+}
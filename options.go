@@ -0,0 +1,211 @@
+package soduku
+
+import (
+	"fmt"
+)
+
+// Engine selects the algorithm SolveGrid uses to complete a grid
+type Engine int
+
+const (
+	// EngineLogical fills in the squares that can be deduced, then searches for the rest.
+	// This is the default engine
+	EngineLogical Engine = iota
+	// EngineDLX models the grid as an exact cover problem and solves it with Dancing Links
+	EngineDLX
+)
+
+// Option changes how a grid is solved
+type Option func(*options)
+
+type options struct {
+	engine Engine
+	log    *SolveLog
+	// custom replaces DefaultStrategies when it is set
+	custom   []Strategy
+	disabled map[Technique]bool
+	// uniqueness enables the techniques that assume the puzzle has a single solution
+	uniqueness bool
+	// boxRows and boxCols are the shape of the boxes, zero for the default shape
+	boxRows int
+	boxCols int
+	// layout replaces the boxes with jigsaw regions when it is set
+	layout *Layout
+	// diagonals adds the two main diagonals as units
+	diagonals bool
+	// units are extra units added after the regions and diagonals
+	units [][]Cell
+	// cages are the cages of a killer grid
+	cages []Cage
+}
+
+// WithEngine selects the engine used to solve the grid
+func WithEngine(e Engine) Option {
+	return func(o *options) {
+		o.engine = e
+	}
+}
+
+// WithSolveLog records every number SolveGrid places, and why, into log
+func WithSolveLog(log *SolveLog) Option {
+	return func(o *options) {
+		o.log = log
+	}
+}
+
+// WithStrategies sets the ordered list of strategies the logical engine tries, in place of
+// DefaultStrategies. After every step the engine starts again from the first strategy, and
+// once none of them can make progress the rest of the grid is searched. Giving no strategies
+// leaves the whole grid to the search
+func WithStrategies(strategies ...Strategy) Option {
+	return func(o *options) {
+		o.custom = append([]Strategy{}, strategies...)
+	}
+}
+
+// WithoutTechniques stops the logical engine from using the given techniques. Squares that
+// would have needed them are left to the search
+func WithoutTechniques(ts ...Technique) Option {
+	return func(o *options) {
+		if o.disabled == nil {
+			o.disabled = map[Technique]bool{}
+		}
+		for _, t := range ts {
+			o.disabled[t] = true
+		}
+	}
+}
+
+// WithUniquenessTechniques lets the logical engine use unique rectangles and BUG+1. They
+// assume the puzzle has a single solution, so SolveGrid only uses them once it has checked
+// that it does
+func WithUniquenessTechniques() Option {
+	return func(o *options) {
+		o.uniqueness = true
+	}
+}
+
+// WithBoxShape sets the shape of the boxes, rows by columns, for a grid whose boxes are not
+// the default shape. The grid has to have rows*cols rows and columns. Without it the shape
+// comes from the size of the grid: 2x2 for 4x4, 2x3 for 6x6, 3x3 for 9x9, 3x4 for 12x12, 4x4
+// for 16x16 and 5x5 for 25x25
+func WithBoxShape(rows, cols int) Option {
+	return func(o *options) {
+		o.boxRows = rows
+		o.boxCols = cols
+	}
+}
+
+// WithLayout solves and checks the grid as a jigsaw, with the regions of the layout in place
+// of the boxes. The grid has to have as many rows as the layout, and any box shape given is
+// ignored
+func WithLayout(l Layout) Option {
+	return func(o *options) {
+		o.layout = &l
+	}
+}
+
+// WithDiagonals solves and checks the grid as Sudoku-X, where both main diagonals must also
+// hold every number once. The diagonals are treated like any other unit, by the search and by
+// the logical techniques
+func WithDiagonals() Option {
+	return func(o *options) {
+		o.diagonals = true
+	}
+}
+
+// WithUnits adds extra units on top of the rows, columns and regions, each of which must also
+// hold every number once. Each unit lists as many squares as the grid has rows, such as the
+// windows of Hyper Sudoku given by HyperWindows. A unit of the wrong size, or one with a square
+// that is off the grid or repeated, makes the grid invalid with a UnitError
+func WithUnits(units ...[]Cell) Option {
+	return func(o *options) {
+		for _, u := range units {
+			o.units = append(o.units, append([]Cell{}, u...))
+		}
+	}
+}
+
+// WithCages solves and checks the grid as a killer sudoku. The numbers in each cage must be
+// different and add up to its sum, on top of the rules of the rows, columns and regions. A
+// square may only be in one cage, but squares do not need to be in any. A cage that cannot be
+// filled makes the grid invalid with a CageError
+func WithCages(cages ...Cage) Option {
+	return func(o *options) {
+		for _, c := range cages {
+			o.cages = append(o.cages, Cage{Cells: append([]Cell{}, c.Cells...), Sum: c.Sum})
+		}
+	}
+}
+
+// newOptions returns the default options with opts applied on top
+func newOptions(opts []Option) options {
+	o := options{engine: EngineLogical}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// strategies returns the strategies to try, in order, leaving out any that have been disabled.
+// The uniqueness techniques are left out unless they have been enabled
+func (o options) strategies() []Strategy {
+	all := o.custom
+	if all == nil {
+		all = DefaultStrategies()
+	}
+	strategies := []Strategy{}
+	for _, s := range all {
+		if !o.disabled[s.Technique()] && (o.uniqueness || !needsUniqueness(s)) {
+			strategies = append(strategies, s)
+		}
+	}
+	return strategies
+}
+
+// geometry returns the layout of the grid, or an error if the grid does not fit it
+func (o options) geometry(grid [][]int) (*geometry, error) {
+	size, regions, err := o.regions(grid)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGrid(grid, size); err != nil {
+		return nil, err
+	}
+
+	extra := []house{}
+	if o.diagonals {
+		extra = append(extra, diagonalHouses(size)...)
+	}
+	units, err := extraHouses(size, o.units)
+	if err != nil {
+		return nil, err
+	}
+	extra = append(extra, units...)
+
+	geo := regionGeometry(size, regions, extra...)
+	if err := geo.addCages(o.cages); err != nil {
+		return nil, err
+	}
+	return geo, nil
+}
+
+// regions returns the size of the grid and its regions, from the layout when there is one and
+// otherwise from the box shape
+func (o options) regions(grid [][]int) (int, []region, error) {
+	if o.layout != nil {
+		return o.layout.size, o.layout.regions, nil
+	}
+
+	rows, cols := o.boxRows, o.boxCols
+	if rows == 0 && cols == 0 {
+		var ok bool
+		if rows, cols, ok = defaultBoxShape(len(grid)); !ok {
+			return 0, nil, &ShapeError{Row: -1, Length: len(grid)}
+		}
+	}
+	if rows < 1 || cols < 1 || rows*cols > maxSize {
+		return 0, nil, fmt.Errorf("invalid box shape %dx%d", rows, cols)
+	}
+	return rows * cols, boxRegions(rows, cols), nil
+}
@@ -0,0 +1,137 @@
+package soduku
+
+import (
+	"fmt"
+)
+
+// Tier is a band of difficulty, from puzzles that only need singles up to those that cannot
+// be finished without searching
+type Tier int
+
+const (
+	// TierEasy puzzles only need naked and hidden singles
+	TierEasy Tier = iota
+	// TierMedium puzzles need locked candidates, pairs or triples
+	TierMedium
+	// TierHard puzzles need quads, x-wings, swordfish, wings or uniqueness techniques
+	TierHard
+	// TierExpert puzzles need jellyfish, coloring or chains
+	TierExpert
+	// TierRequiresSearch puzzles cannot be finished by the logical solver and need guessing
+	TierRequiresSearch
+)
+
+var tierNames = map[Tier]string{
+	TierEasy:           "easy",
+	TierMedium:         "medium",
+	TierHard:           "hard",
+	TierExpert:         "expert",
+	TierRequiresSearch: "requires search",
+}
+
+func (t Tier) String() string {
+	if name, ok := tierNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Tier(%d)", int(t))
+}
+
+// MarshalText writes the tier by name, so it reads well in JSON
+func (t Tier) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// Rating is the difficulty of a puzzle. Score orders puzzles by the weight of the hardest
+// technique they need, then by the number of logical steps taken, so a higher score is
+// always at least as hard
+type Rating struct {
+	Tier    Tier      `json:"tier"`
+	Score   int       `json:"score"`
+	Hardest Technique `json:"hardest"`
+	Steps   int       `json:"steps"`
+}
+
+// techniqueRating is the tier of a technique, and its weight within the score
+type techniqueRating struct {
+	tier   Tier
+	weight int
+}
+
+// techniqueRatings rates every built in technique. Techniques not listed, such as those of
+// custom strategies, are rated as unknownRating
+var techniqueRatings = map[Technique]techniqueRating{
+	NakedSingle:        {tier: TierEasy, weight: 1},
+	HiddenSingle:       {tier: TierEasy, weight: 2},
+	PointingCandidates: {tier: TierMedium, weight: 3},
+	BoxLineReduction:   {tier: TierMedium, weight: 3},
+	CageSum:            {tier: TierMedium, weight: 3},
+	NakedPair:          {tier: TierMedium, weight: 4},
+	HiddenPair:         {tier: TierMedium, weight: 5},
+	NakedTriple:        {tier: TierMedium, weight: 6},
+	HiddenTriple:       {tier: TierMedium, weight: 7},
+	NakedQuad:          {tier: TierHard, weight: 8},
+	HiddenQuad:         {tier: TierHard, weight: 9},
+	XWing:              {tier: TierHard, weight: 10},
+	Swordfish:          {tier: TierHard, weight: 12},
+	XYWing:             {tier: TierHard, weight: 12},
+	XYZWing:            {tier: TierHard, weight: 13},
+	WWing:              {tier: TierHard, weight: 13},
+	UniqueRectangle1:   {tier: TierHard, weight: 11},
+	UniqueRectangle2:   {tier: TierHard, weight: 12},
+	UniqueRectangle3:   {tier: TierHard, weight: 13},
+	UniqueRectangle4:   {tier: TierHard, weight: 13},
+	BUGPlusOne:         {tier: TierHard, weight: 12},
+	Jellyfish:          {tier: TierExpert, weight: 15},
+	SimpleColoring:     {tier: TierExpert, weight: 14},
+	XChain:             {tier: TierExpert, weight: 16},
+	XYChain:            {tier: TierExpert, weight: 17},
+	AIC:                {tier: TierExpert, weight: 20},
+	Search:             {tier: TierRequiresSearch, weight: 25},
+}
+
+// unknownRating is used for techniques that are not in techniqueRatings
+var unknownRating = techniqueRating{tier: TierExpert, weight: 20}
+
+// maxScoredSteps caps the steps counted in the score, so they never outweigh the technique
+const maxScoredSteps = 99
+
+// Rate solves the grid with the logical engine and rates it by the hardest technique needed.
+// Puzzles that need the search to finish are rated TierRequiresSearch, with Search as the
+// hardest technique. Options are passed to SolveGrid, so uniqueness techniques or custom
+// strategies are taken into account when given, but the engine is always the logical one
+func Rate(grid [][]int, opts ...Option) (Rating, error) {
+	log := &SolveLog{}
+	opts = append(append([]Option{}, opts...), WithSolveLog(log), WithEngine(EngineLogical))
+	if _, _, err := SolveGrid(grid, opts...); err != nil {
+		return Rating{}, err
+	}
+
+	r := Rating{Tier: TierEasy}
+	hardest := techniqueRating{}
+	for _, s := range log.Steps {
+		tr := ratingOf(s.Technique)
+		if tr.weight > hardest.weight {
+			hardest = tr
+			r.Hardest = s.Technique
+		}
+		if s.Technique != Search {
+			r.Steps++
+		}
+	}
+	r.Tier = hardest.tier
+
+	steps := r.Steps
+	if steps > maxScoredSteps {
+		steps = maxScoredSteps
+	}
+	r.Score = hardest.weight*(maxScoredSteps+1) + steps
+	return r, nil
+}
+
+// ratingOf returns the rating of the technique
+func ratingOf(t Technique) techniqueRating {
+	if tr, ok := techniqueRatings[t]; ok {
+		return tr
+	}
+	return unknownRating
+}
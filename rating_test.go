@@ -0,0 +1,112 @@
+package soduku
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRate(t *testing.T) {
+	tt := []struct {
+		description   string
+		input         string
+		expectTier    Tier
+		expectHardest Technique
+	}{
+		{
+			description:   "easy",
+			input:         readmeGrid,
+			expectTier:    TierEasy,
+			expectHardest: NakedSingle,
+		},
+		{
+			description:   "medium",
+			input:         "020950000107000000805002007000300410001400902040000070000006000600008000010000053",
+			expectTier:    TierMedium,
+			expectHardest: NakedPair,
+		},
+		{
+			description:   "hard",
+			input:         "007080002290100408060020703480006000000098000000400039004009010030070006001000000",
+			expectTier:    TierHard,
+			expectHardest: XWing,
+		},
+		{
+			description:   "expert",
+			input:         "280006000006500000500908000010407005603090000000800000000300050900000087030002010",
+			expectTier:    TierExpert,
+			expectHardest: AIC,
+		},
+		{
+			description:   "requires search",
+			input:         "800000000003600000070090200050007000000045700000100030001000068008500010090000400",
+			expectTier:    TierRequiresSearch,
+			expectHardest: Search,
+		},
+	}
+
+	lastScore := -1
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+
+			r, err := g.Rate()
+			require.Nil(t, err)
+			assert.Equal(t, td.expectTier, r.Tier)
+			assert.Equal(t, td.expectHardest, r.Hardest)
+
+			// The cases are in order of difficulty
+			assert.True(t, r.Score > lastScore)
+			lastScore = r.Score
+		})
+	}
+}
+
+func TestRateScore(t *testing.T) {
+	g, err := ParseGrid(readmeGrid)
+	require.Nil(t, err)
+
+	// Rating ignores the engine, and only counts the logical steps
+	r, err := Rate(g.Rows(), WithEngine(EngineDLX))
+	require.Nil(t, err)
+	assert.Equal(t, Rating{Tier: TierEasy, Score: 145, Hardest: NakedSingle, Steps: 45}, r)
+
+	// Without naked singles the puzzle needs hidden singles, which weigh more
+	r, err = Rate(g.Rows(), WithoutTechniques(NakedSingle))
+	require.Nil(t, err)
+	assert.Equal(t, HiddenSingle, r.Hardest)
+	assert.True(t, r.Score > 145)
+}
+
+func TestRateKeepsOptions(t *testing.T) {
+	g, err := ParseGrid(readmeGrid)
+	require.Nil(t, err)
+
+	// The options Rate adds must not land in the room left after the caller's
+	opts := make([]Option, 1, 4)
+	opts[0] = WithEngine(EngineDLX)
+	_, err = Rate(g.Rows(), opts...)
+	require.Nil(t, err)
+	for _, o := range opts[1:cap(opts)] {
+		assert.Nil(t, o)
+	}
+}
+
+func TestRateInvalid(t *testing.T) {
+	_, err := Rate([][]int{{1}})
+	assert.IsType(t, &ShapeError{}, err)
+
+	grid := emptyTestGrid()
+	grid[0][0], grid[0][1] = 1, 1
+	_, err = Rate(grid)
+	assert.NotNil(t, err)
+}
+
+func TestRatingJSON(t *testing.T) {
+	out, err := json.Marshal(Rating{Tier: TierRequiresSearch, Score: 2500, Hardest: Search})
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"tier": "requires search", "score": 2500, "hardest": "search", "steps": 0}`, string(out))
+}
@@ -0,0 +1,115 @@
+package soduku
+
+// nakedSingle returns the step placing the only possible number at the position, or nil if
+// more than one number is possible. The reasons are one filled peer for each other number,
+// numbers removed by other techniques have no filled peer to give
+func nakedSingle(b *CandidateBoard, pos position) *Step {
+	p := b.candidates(pos)
+	if p.count() != 1 {
+		return nil
+	}
+	num := p.numbers()[0]
+	step := &Step{
+		Technique: NakedSingle,
+		Cell:      Cell{Row: pos.rowNumber, Col: pos.colNumber},
+		Digit:     num,
+	}
+	for other := 1; other <= b.geo.size; other++ {
+		if other == num {
+			continue
+		}
+		if c, ok := b.peerHolding(pos, other); ok {
+			step.Reasons = append(step.Reasons, c)
+		}
+	}
+	return step
+}
+
+// hiddenSingle returns the step placing a number that fits only one square of the house, or
+// nil if there is none. The reasons are one filled peer ruling out each other empty square,
+// where the number was not removed by another technique
+func hiddenSingle(b *CandidateBoard, h house) *Step {
+	used := digitSet(0)
+	for _, pos := range h.positions {
+		used |= 1 << uint(b.grid[pos.rowNumber][pos.colNumber])
+	}
+
+	for num := 1; num <= b.geo.size; num++ {
+		if used.has(num) {
+			continue
+		}
+		var only *position
+		count := 0
+		for i, pos := range h.positions {
+			if b.candidates(pos).has(num) {
+				only = &h.positions[i]
+				count++
+			}
+		}
+		if count != 1 {
+			continue
+		}
+
+		step := &Step{
+			Technique: HiddenSingle,
+			Cell:      Cell{Row: only.rowNumber, Col: only.colNumber},
+			Digit:     num,
+		}
+		for _, pos := range h.positions {
+			if pos == *only || b.grid[pos.rowNumber][pos.colNumber] != 0 {
+				continue
+			}
+			if c, ok := b.peerHolding(pos, num); ok {
+				step.Reasons = append(step.Reasons, c)
+			}
+		}
+		return step
+	}
+	return nil
+}
+
+// findNakedSingle returns the first naked single in reading order
+func findNakedSingle(b *CandidateBoard) *Step {
+	for _, pos := range getEmptySquares(b.grid) {
+		if step := nakedSingle(b, pos); step != nil {
+			return step
+		}
+	}
+	return nil
+}
+
+// findHiddenSingle returns the first hidden single in the rows, then columns, then boxes
+func findHiddenSingle(b *CandidateBoard) *Step {
+	for _, h := range b.geo.houses {
+		if step := hiddenSingle(b, h); step != nil {
+			return step
+		}
+	}
+	return nil
+}
+
+// findHiddenSingleIn returns a technique finding the first hidden single in the houses of the
+// given kinds
+func findHiddenSingleIn(kinds ...UnitKind) func(b *CandidateBoard) *Step {
+	return func(b *CandidateBoard) *Step {
+		for _, h := range b.geo.houses {
+			if !containsKind(kinds, h.Kind) {
+				continue
+			}
+			if step := hiddenSingle(b, h); step != nil {
+				return step
+			}
+		}
+		return nil
+	}
+}
+
+// containsKind returns whether kind is in kinds
+func containsKind(kinds []UnitKind, kind UnitKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -1,308 +1,290 @@
 package soduku
 
 import (
+	"context"
 	"errors"
 	"fmt"
 )
 
+// ErrNoSolution is returned when a grid is valid but cannot be completed
+var ErrNoSolution = errors.New("the grid has no solution")
+
 // CheckedGrid stores whether a grid is valid, and complete
 type CheckedGrid struct {
 	Complete bool
 	Message  string
-	Valid    bool
+	// Unique is only set by SolveGrid, it reports whether the puzzle it was given has
+	// exactly one solution
+	Unique bool
+	Valid  bool
+	// Conflicts lists every number that appears more than once in a unit, in the order rows,
+	// columns, boxes, diagonals then extra units
+	Conflicts []Conflict
+	// CageConflicts lists every cage of a killer grid that is broken, in the order the cages
+	// were given
+	CageConflicts []CageConflict
 }
 
-type adjacentToCheck struct {
-	adjacentRows []int
-	adjacentCols []int
+// Conflict is a number that appears more than once in a unit. Cells holds the positions of
+// every copy of the number in the unit, in reading order
+type Conflict struct {
+	Unit
+	Digit int    `json:"digit"`
+	Cells []Cell `json:"cells"`
 }
 
-// SolveGrid attempts to solve a given suduko board. It returns the grid as complete as it
-// could achieve, and a struct indicating the status of the grid
-func SolveGrid(grid [][]int) ([][]int, CheckedGrid, error) {
-	// previousNumSquares holds the previous loops count of how many empty squares exist
-	previousNumSquares := 0
-
-	cg := CheckedGrid{}
-
-	for {
-		ss, err := NewSquares(grid)
-		if err != nil {
-			return nil, cg, err
-		}
+// SolveGrid attempts to solve a given suduko board. Squares that can be deduced are filled
+// first, using DefaultStrategies or the list given with WithStrategies, and the rest of the
+// grid is completed by a depth first search. It returns the solved grid and a struct
+// indicating the status of the grid, or ErrNoSolution if the grid cannot be completed. The
+// engine used can be changed with WithEngine.
+//
+// The given grid is never modified, SolveGrid works on a copy. When an error is returned the
+// returned grid holds the squares that were filled in before the error was found
+func SolveGrid(grid [][]int, opts ...Option) ([][]int, CheckedGrid, error) {
+	o := newOptions(opts)
+	geo, err := o.geometry(grid)
+	if err != nil {
+		return grid, CheckedGrid{Message: err.Error()}, err
+	}
+	grid = copyGrid(grid)
+	if cg := checkGrid(geo, grid); !cg.Valid {
+		return grid, cg, errors.New("the grid is invalid")
+	}
 
-		if len(ss) == 0 || len(ss) == previousNumSquares {
-			break
+	if o.engine == EngineDLX {
+		// Count the solutions before any square is filled in, stopping once a second is found
+		unique := countSolutions(geo, grid, 2) == 1
+		solved, cg, err := solveGridDLX(geo, grid)
+		cg.Unique = unique
+		if err == nil {
+			recordSearch(o.log, grid, solved)
 		}
-		previousNumSquares = len(ss)
+		return solved, cg, err
+	}
 
-		for _, s := range ss {
-			if len(s.possibleNums) == 1 {
-				grid[s.pos.rowNumber][s.pos.colNumber] = s.possibleNums[0]
-			}
-		}
-		ss, err = NewSquares(grid)
-		if err != nil {
-			return nil, cg, err
-		}
-		for _, s := range ss {
-			if err := traverseAdjacent(grid, s); err != nil {
-				return nil, cg, err
-			}
-		}
+	// Uniqueness techniques could remove the answer from a puzzle with more than one solution.
+	// The other techniques keep every solution, so the solutions are counted on a copy of the
+	// board they leave, stopping once a second is found
+	b := newCandidateBoard(geo, grid)
+	uniqueness := o.uniqueness
+	o.uniqueness = false
+	if uniqueness {
+		reduced := b.clone()
+		solveLogically(reduced, o.strategies(), nil)
+		o.uniqueness = countSolutions(geo, reduced.grid, 2) == 1
 	}
-	cg = CheckGrid(grid)
+	solveLogically(b, o.strategies(), o.log)
+	cg := checkGrid(geo, grid)
 	if !cg.Valid {
 		return grid, cg, errors.New("the grid is invalid")
 	}
+	// Only the answer is left once the techniques complete the grid, otherwise the search for
+	// it also tells whether it is the only one
+	cg.Unique = cg.Complete
 	if cg.Complete {
 		return grid, cg, nil
 	}
-	var err error
-	grid, err = bruteForceGuess(grid)
+	solved, unique, err := bruteForceGuess(geo, grid)
 	if err != nil {
 		return grid, cg, err
 	}
-	cg = CheckGrid(grid)
+	recordSearch(o.log, grid, solved)
+	cg = checkGrid(geo, solved)
+	cg.Unique = unique
 	if !cg.Valid {
-		return grid, cg, errors.New("the grid is invalid after brute forcing")
+		return solved, cg, errors.New("the grid is invalid after brute forcing")
 	}
-	return grid, cg, err
+	return solved, cg, nil
 }
 
-// CheckGrid returns where a given grid is complete, and if it is valid
-func CheckGrid(grid [][]int) CheckedGrid {
-	cg := CheckedGrid{Valid: true, Complete: true, Message: ""}
+// solveLogically fills in every square that can be deduced, recording each step in the log.
+// After every step it starts again from the easiest technique, and it stops once no technique
+// can make progress
+func solveLogically(b *CandidateBoard, strategies []Strategy, log *SolveLog) {
+	for {
+		step := nextStep(b, strategies)
+		if step == nil {
+			return
+		}
+		b.apply(step)
+		log.add(*step)
+	}
+}
 
-	// Check all rows
-	totalRows := 0
-	for rowNum, row := range grid {
-		totalRows++
-		foundNumbersRows := make(map[int]int, 9)
+// SolveGridInPlace solves the grid like SolveGrid, but writes the solution into the given
+// grid. The grid is only written to when the solve succeeds, if an error is returned the grid
+// is left exactly as it was given
+func SolveGridInPlace(grid [][]int, opts ...Option) (CheckedGrid, error) {
+	solved, cg, err := SolveGrid(grid, opts...)
+	if err != nil {
+		return cg, err
+	}
+	for i := range grid {
+		copy(grid[i], solved[i])
+	}
+	return cg, nil
+}
 
-		for i := 0; i <= 8; i++ {
-			if row[i] > 0 {
-				foundNumbersRows[row[i]]++
-			} else {
-				cg.Complete = false
-			}
-		}
-		for num, count := range foundNumbersRows {
-			if count != 1 {
-				cg.Complete = false
-				if count > 1 {
-					cg.Message = fmt.Sprintf("%s A duplicate of %d was found in row %d\n", cg.Message, num, rowNum)
-					cg.Valid = false
-				}
-			}
-		}
+// solveGridDLX solves the grid with the Dancing Links engine
+func solveGridDLX(geo *geometry, grid [][]int) ([][]int, CheckedGrid, error) {
+	cg := checkGrid(geo, grid)
+	if !cg.Valid {
+		return grid, cg, errors.New("the grid is invalid")
 	}
-	if totalRows != 9 {
-		cg.Message = fmt.Sprintf("%s Expected 9 rows, found %d", cg.Message, totalRows)
-		cg.Valid = false
-		cg.Complete = false
+	solved, err := solveDLX(geo, grid)
+	if err != nil {
+		return grid, cg, err
 	}
+	return solved, checkGrid(geo, solved), nil
+}
 
-	// Check all columns
-	for colNum := 0; colNum <= 8; colNum++ {
-		foundNumbersCol := make(map[int]int, 9)
-		for rowNum := 0; rowNum <= 8; rowNum++ {
-			num := grid[rowNum][colNum]
-			if num > 0 {
-				foundNumbersCol[num]++
-			} else {
-				cg.Complete = false
-			}
-		}
+// CheckGrid returns where a given grid is complete, and if it is valid. Options give the
+// layout of the grid, as for SolveGrid. A grid that does not fit the layout, or holds numbers
+// outside 0 to its size, is reported as invalid
+func CheckGrid(grid [][]int, opts ...Option) CheckedGrid {
+	geo, err := newOptions(opts).geometry(grid)
+	if err != nil {
+		return CheckedGrid{Message: err.Error()}
+	}
+	return checkGrid(geo, grid)
+}
 
-		for num, count := range foundNumbersCol {
-			if count != 1 {
+// checkGrid checks every house of the geometry for duplicates, and every cage for duplicates
+// and its sum. The grid must fit the geometry
+func checkGrid(geo *geometry, grid [][]int) CheckedGrid {
+	cg := CheckedGrid{Valid: true, Complete: true, Message: ""}
+	for _, row := range grid {
+		for _, num := range row {
+			if num == 0 {
 				cg.Complete = false
-				if count > 1 {
-					cg.Message = fmt.Sprintf("%s A duplicate of %d was found in column %d\n", cg.Message, num, colNum)
-					cg.Valid = false
-				}
 			}
 		}
 	}
 
-	// Check all the regions
-	for _, reg := range allRegions {
-		foundNumbersGrid := make(map[int]int, 9)
-		for row := reg.minRowNumber; row <= reg.maxRowNumber; row++ {
-			for col := reg.minColNumber; col <= reg.maxColNumber; col++ {
-				num := grid[row][col]
-				if num > 0 {
-					foundNumbersGrid[num]++
-				}
-			}
-		}
-		for num, count := range foundNumbersGrid {
-			if count != 1 {
-				cg.Complete = false
-				if count > 1 {
-					gridPosition := fmt.Sprintf("rowNumber {%d, %d}, colNumber {%d, %d}",
-						reg.minRowNumber, reg.maxRowNumber, reg.minColNumber, reg.maxColNumber)
-					cg.Message = fmt.Sprintf("%s A duplicate of %d was found in grid %q\n", cg.Message, num, gridPosition)
-					cg.Valid = false
-				}
+	for _, h := range geo.houses {
+		found := make([][]Cell, geo.size+1)
+		for _, pos := range h.positions {
+			if num := grid[pos.rowNumber][pos.colNumber]; num > 0 {
+				found[num] = append(found[num], Cell{Row: pos.rowNumber, Col: pos.colNumber})
 			}
 		}
+		cg.addDuplicates(h.Unit, found, geo.describe(h))
 	}
+	cg.addCageConflicts(geo, grid)
 	return cg
 }
 
-// traverseAdjacent looks at the rows and columns next to the position to find entries
-// For example if there if the grid looks like this
-//
-// 1, 0, 0, 0, 0, 0, 0, 0, 0
-// 0, 0, 0, 0, 0, 0, 0, 0, 0
-// 0, 0, 0, 0, 1, 0, 0, 0, 0
-// 0, 0, 0, 0, 0, 0, 1, 0, 0
-// 0, 0, 0, 0, 0, 0, 0, 0, 0
-// 0, 0, 0, 0, 0, 0, 0, 0, 0
-// 0, 0, 0, 0, 0, 0, 0, 1, 0
-// 0, 0, 0, 0, 0, 0, 0, 0, 0
-// 0, 0, 0, 0, 0, 0, 0, 0, 0
-//
-// Then at position {1,8} there has to be a 1, as it cannot go anywhere else in the top right grid
-func traverseAdjacent(grid [][]int, s *square) error {
-	r := adjacentRowsAndCols(s.reg, s.pos)
-
-	for _, num := range s.possibleNums {
-		foundNumColAndRow := 0
-		foundNum := 0
-		// check the adjacent columns
-		for i := 0; i <= 8; i++ {
-
-			for _, c := range r.adjacentCols {
-				if grid[i][c] == num {
-					foundNum++
-					if foundNum == 2 {
-						// We found the number in both adjacent columns, so it has to be in this column
-						// Now check to see if the boxes next to the position are populated, if they
-						// are we know this is the correct position for this number
-						foundNumColAndRow++
-
-						alreadyPopulated := 0
-						for _, r := range r.adjacentRows {
-							if grid[r][s.pos.colNumber] != 0 {
-								alreadyPopulated++
-							}
-						}
-						if alreadyPopulated == 2 {
-							grid[s.pos.rowNumber][s.pos.colNumber] = num
-						}
-						break
-					}
-				}
-			}
+// addDuplicates records a conflict for every number found more than once in the unit, found
+// holds the squares of each number. where describes the unit in the human readable message
+func (cg *CheckedGrid) addDuplicates(u Unit, found [][]Cell, where string) {
+	for num, cells := range found {
+		if len(cells) <= 1 {
+			continue
 		}
+		cg.Complete = false
+		cg.Valid = false
+		cg.Message = fmt.Sprintf("%s A duplicate of %d was found in %s\n", cg.Message, num, where)
+		cg.Conflicts = append(cg.Conflicts, Conflict{Unit: u, Digit: num, Cells: cells})
+	}
+}
 
-		foundNum = 0
-		for _, row := range r.adjacentRows {
-
-			for col := 0; col <= 8; col++ {
-				if grid[row][col] == num {
-					foundNum++
-				}
-				if foundNum == 2 {
-					// We found the number in both adjacent rows, so it has to be in this row
-					// Now check to see if the boxes next to the position are populated, if they
-					// are we know this is the correct position for this number
-					foundNumColAndRow++
-					alreadyPopulated := 0
-					for _, c := range r.adjacentCols {
-						if grid[s.pos.rowNumber][c] != 0 {
-							alreadyPopulated++
-						}
-					}
-					if alreadyPopulated == 2 {
-						grid[s.pos.rowNumber][s.pos.colNumber] = num
-					}
-					break
-				}
-			}
+// bruteForceGuess completes the grid by searching, with the exact cover engine or with guess
+// for a killer grid. It returns a completed copy of the grid and whether it is the only one,
+// or ErrNoSolution if no combination of numbers can complete it
+func bruteForceGuess(geo *geometry, grid [][]int) ([][]int, bool, error) {
+	var solution [][]int
+	count := 0
+	keep := func(g [][]int) bool {
+		if count == 0 {
+			solution = copyGrid(g)
 		}
-
-		// Because the entry was identified in both row and column, we know this is the correct location
-		// even though there is empty boxes next to the position
-		if foundNumColAndRow == 2 {
-			grid[s.pos.rowNumber][s.pos.colNumber] = num
+		count++
+		return count == 2
+	}
+	if len(geo.cages) > 0 {
+		if _, err := search(context.Background(), geo, grid, keep); err != nil {
+			return nil, false, err
 		}
+	} else {
+		newDLX(geo, grid).search(func(ps []dlxPlacement) bool {
+			return keep(placementsToGrid(geo.size, ps))
+		})
 	}
-	return nil
+	if count == 0 {
+		return nil, false, ErrNoSolution
+	}
+	return solution, count == 1, nil
 }
 
-// bruteForceGuess adds in numbers to empty positions and sees if it can solve the rest of the grid
-// This is a weak brute force, it should try combinations of numbers
-func bruteForceGuess(grid [][]int) ([][]int, error) {
-	ss, err := NewSquares(grid)
-	if err != nil {
-		return nil, err
+// guessPropagation are the strategies guess applies before each guess. CageSum comes first, so
+// every cage is consistent before a single is placed in it. Harder techniques cost more than
+// the guesses they save
+var guessPropagation = []Strategy{
+	NewStrategy(CageSum, findCageSum),
+	NewStrategy(NakedSingle, findNakedSingle),
+	NewStrategy(HiddenSingle, findHiddenSingle),
+	NewStrategy(PointingCandidates, findPointing),
+	NewStrategy(BoxLineReduction, findBoxLineReduction),
+}
+
+// search calls found with every completion of the grid until it returns true, like guess, and
+// leaves the grid unchanged
+func search(ctx context.Context, geo *geometry, grid [][]int, found func([][]int) bool) (bool, error) {
+	return guess(ctx, newCandidateBoard(geo, copyGrid(grid)), found)
+}
+
+// guess performs a depth first search over the empty squares of the board, calling found each
+// time the grid is completed. Returning true from found stops the search, guess then returns
+// true. Before each guess it fills in the singles and removes the candidates the easiest
+// techniques can, then it tries each candidate of the square with the fewest on a copy of the
+// board, so forced squares are filled before any real guessing happens
+func guess(ctx context.Context, cb *CandidateBoard, found func([][]int) bool) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	for step := nextStep(cb, guessPropagation); step != nil; step = nextStep(cb, guessPropagation) {
+		cb.apply(step)
 	}
 
-	// poss := getEmptyPositions(grid)
-	copyGrid := func(grid [][]int) [][]int {
-		tempGrid := make([][]int, len(grid))
-		for i := range grid {
-			tempGrid[i] = make([]int, len(grid[i]))
-			copy(tempGrid[i], grid[i])
+	// A number with no square left in a house cannot be placed
+	for _, h := range cb.geo.houses {
+		left := cb.geo.digits
+		for _, pos := range h.positions {
+			left &^= 1<<uint(cb.grid[pos.rowNumber][pos.colNumber]) | cb.candidates(pos)
+		}
+		if left != 0 {
+			return false, nil
 		}
-		return tempGrid
 	}
 
-	tempGrid := copyGrid(grid)
-
-	for _, s := range ss {
-		if len(s.possibleNums) == 1 {
-			tempGrid[s.pos.rowNumber][s.pos.colNumber] = s.possibleNums[0]
+	pos, cands, ok := cb.mostConstrained()
+	if !ok {
+		// A single can still break a house whose other squares ran out of candidates
+		if !checkGrid(cb.geo, cb.grid).Valid {
+			return false, nil
 		}
+		return found(cb.grid), nil
+	}
 
-		if err := traverseAdjacent(tempGrid, s); err != nil {
-			return nil, err
-		}
-		cg := CheckGrid(tempGrid)
-		if !cg.Valid {
-			tempGrid = copyGrid(grid)
-			continue
-		}
-		if cg.Complete {
-			return tempGrid, nil
+	for _, num := range cands.numbers() {
+		next := cb.clone()
+		next.place(pos.rowNumber, pos.colNumber, num)
+		if stop, err := guess(ctx, next, found); err != nil || stop {
+			return stop, err
 		}
 	}
-	return grid, nil
+	return false, nil
 }
 
-// adjacentRowsAndCols the rows and columns next to the position, but within the same grid
-func adjacentRowsAndCols(reg region, pos position) adjacentToCheck {
-	r := adjacentToCheck{}
-
-	switch reg.maxRowNumber - pos.rowNumber {
-	case 0:
-		r.adjacentRows = append(r.adjacentRows, pos.rowNumber-1)
-		r.adjacentRows = append(r.adjacentRows, pos.rowNumber-2)
-	case 1:
-		r.adjacentRows = append(r.adjacentRows, pos.rowNumber+1)
-		r.adjacentRows = append(r.adjacentRows, pos.rowNumber-1)
-	case 2:
-		r.adjacentRows = append(r.adjacentRows, pos.rowNumber+1)
-		r.adjacentRows = append(r.adjacentRows, pos.rowNumber+2)
+// copyGrid returns a deep copy of the grid
+func copyGrid(grid [][]int) [][]int {
+	tempGrid := make([][]int, len(grid))
+	for i := range grid {
+		tempGrid[i] = make([]int, len(grid[i]))
+		copy(tempGrid[i], grid[i])
 	}
-
-	switch reg.maxColNumber - pos.colNumber {
-	case 0:
-		r.adjacentCols = append(r.adjacentCols, pos.colNumber-1)
-		r.adjacentCols = append(r.adjacentCols, pos.colNumber-2)
-	case 1:
-		r.adjacentCols = append(r.adjacentCols, pos.colNumber+1)
-		r.adjacentCols = append(r.adjacentCols, pos.colNumber-1)
-	case 2:
-		r.adjacentCols = append(r.adjacentCols, pos.colNumber+1)
-		r.adjacentCols = append(r.adjacentCols, pos.colNumber+2)
-	}
-
-	return r
+	return tempGrid
 }
 
 // PrintGrid prints out the grid to the terminal
@@ -1,7 +1,6 @@
 package soduku
 
 import (
-	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -244,7 +243,7 @@ func TestSolveGridSimple(t *testing.T) {
 		t.Run(td.description, func(t *testing.T) {
 			output, cg, err := SolveGrid(td.input)
 			require.Nil(t, err)
-			assert.Equal(t, CheckedGrid{Valid: true, Complete: true}, cg)
+			assert.Equal(t, CheckedGrid{Valid: true, Complete: true, Unique: true}, cg)
 
 			if td.printGrid {
 				PrintGrid(output)
@@ -274,18 +273,7 @@ func TestSolveGridMedium(t *testing.T) {
 				[]int{6, 7, 8, 9, 0, 2, 3, 4, 5},
 				[]int{0, 0, 2, 3, 4, 5, 6, 7, 8},
 			},
-			expectComplete: false,
-			expectOutput: [][]int{
-				[]int{0, 2, 3, 0, 5, 6, 7, 8, 9},
-				[]int{0, 5, 6, 0, 8, 9, 1, 2, 3},
-				[]int{0, 8, 9, 0, 2, 3, 4, 5, 6},
-				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
-				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
-				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
-				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
-				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
-				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
-			},
+			expectComplete: true,
 		},
 		{
 			description: "two",
@@ -300,18 +288,7 @@ func TestSolveGridMedium(t *testing.T) {
 				[]int{0, 7, 8, 9, 0, 0, 3, 4, 5},
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 			},
-			expectComplete: false,
-			expectOutput: [][]int{
-				[]int{0, 0, 0, 0, 5, 0, 7, 8, 9},
-				[]int{0, 5, 0, 0, 8, 0, 1, 2, 3},
-				[]int{0, 8, 0, 0, 2, 0, 4, 5, 6},
-				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
-				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
-				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
-				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
-				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
-				[]int{0, 0, 0, 3, 4, 5, 6, 7, 8},
-			},
+			expectComplete: true,
 		},
 		{
 			description: "three",
@@ -326,18 +303,7 @@ func TestSolveGridMedium(t *testing.T) {
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 			},
-			expectComplete: false,
-			expectOutput: [][]int{
-				[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
-				[]int{4, 5, 6, 7, 8, 9, 0, 0, 1},
-				[]int{7, 8, 9, 1, 2, 3, 4, 5, 6},
-				[]int{0, 0, 0, 0, 0, 0, 1, 0, 0},
-				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
-				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
-				[]int{0, 0, 0, 0, 0, 0, 0, 1, 0},
-				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
-				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
-			},
+			expectComplete: true,
 		},
 		{
 			description: "four",
@@ -352,18 +318,7 @@ func TestSolveGridMedium(t *testing.T) {
 				[]int{6, 0, 8, 0, 0, 0, 1, 0, 0},
 				[]int{9, 0, 2, 0, 0, 0, 0, 0, 0},
 			},
-			expectComplete: false,
-			expectOutput: [][]int{
-				[]int{1, 2, 3, 0, 0, 0, 0, 0, 0},
-				[]int{4, 5, 6, 0, 0, 0, 0, 0, 0},
-				[]int{7, 8, 9, 0, 0, 0, 0, 0, 0},
-				[]int{2, 3, 4, 0, 0, 0, 0, 0, 0},
-				[]int{5, 6, 7, 0, 0, 0, 0, 0, 0},
-				[]int{8, 9, 1, 0, 0, 0, 0, 0, 0},
-				[]int{3, 0, 5, 1, 0, 0, 0, 0, 0},
-				[]int{6, 0, 8, 0, 0, 0, 1, 0, 0},
-				[]int{9, 1, 2, 0, 0, 0, 0, 0, 0},
-			},
+			expectComplete: true,
 		},
 		{
 			description: "five",
@@ -378,18 +333,7 @@ func TestSolveGridMedium(t *testing.T) {
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 			},
-			expectComplete: false,
-			expectOutput: [][]int{
-				[]int{1, 0, 0, 0, 0, 0, 0, 0, 0},
-				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
-				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
-				[]int{0, 1, 0, 0, 0, 0, 0, 0, 0},
-				[]int{0, 0, 0, 0, 0, 0, 1, 0, 0},
-				[]int{0, 0, 0, 1, 0, 0, 0, 0, 0},
-				[]int{0, 0, 1, 0, 0, 0, 0, 0, 0},
-				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
-				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
-			},
+			expectComplete: true,
 		},
 	}
 
@@ -417,6 +361,9 @@ func TestSolveGridRealExamples(t *testing.T) {
 		input          [][]int
 		expectComplete bool
 		expectOutput   [][]int
+		// expectRating and expectRatingErr pin the ExplainerRating of the puzzle
+		expectRating    float64
+		expectRatingErr error
 	}{
 		{
 			description: "one",
@@ -432,6 +379,7 @@ func TestSolveGridRealExamples(t *testing.T) {
 				[]int{0, 0, 0, 1, 0, 0, 3, 0, 5},
 			},
 			expectComplete: true,
+			expectRating:   1.2,
 		},
 		{
 			description: "two",
@@ -447,6 +395,23 @@ func TestSolveGridRealExamples(t *testing.T) {
 				[]int{4, 0, 1, 9, 6, 0, 0, 7, 5},
 			},
 			expectComplete: true,
+			expectRating:   1.2,
+		},
+		{
+			description: "hard, requires searching",
+			input: [][]int{
+				[]int{8, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 3, 6, 0, 0, 0, 0, 0},
+				[]int{0, 7, 0, 0, 9, 0, 2, 0, 0},
+				[]int{0, 5, 0, 0, 0, 7, 0, 0, 0},
+				[]int{0, 0, 0, 0, 4, 5, 7, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 0, 3, 0},
+				[]int{0, 0, 1, 0, 0, 0, 0, 6, 8},
+				[]int{0, 0, 8, 5, 0, 0, 0, 1, 0},
+				[]int{0, 9, 0, 0, 0, 0, 4, 0, 0},
+			},
+			expectComplete:  true,
+			expectRatingErr: ErrRequiresSearch,
 		},
 	}
 
@@ -462,98 +427,176 @@ func TestSolveGridRealExamples(t *testing.T) {
 			if !td.expectComplete {
 				assert.Equal(t, td.expectOutput, output)
 			}
-			assert.Equal(t, CheckedGrid{Valid: true, Complete: td.expectComplete}, cg)
+			assert.Equal(t, CheckedGrid{Valid: true, Complete: td.expectComplete, Unique: true}, cg)
+
+			rating, err := ExplainerRating(td.input)
+			assert.Equal(t, td.expectRatingErr, err)
+			assert.Equal(t, td.expectRating, rating)
 		})
 	}
 }
 
-func TestRemainingColsToCheck(t *testing.T) {
+func TestBruteForceGuess(t *testing.T) {
 	tt := []struct {
-		description    string
-		ep             position
-		reg            region
-		expectedResult adjacentToCheck
+		description  string
+		input        [][]int
+		expectOutput [][]int
+		expectUnique bool
+		expectErr    error
 	}{
 		{
-			description: "first",
-			ep: position{
-				colNumber: 0,
-				rowNumber: 0,
-			},
-			reg: region{
-				minColNumber: 0,
-				maxColNumber: 2,
-				minRowNumber: 0,
-				maxRowNumber: 2,
+			description: "completes the grid",
+			input: [][]int{
+				[]int{0, 0, 0, 4, 5, 6, 7, 8, 9},
+				[]int{0, 0, 0, 7, 8, 9, 1, 2, 3},
+				[]int{0, 0, 0, 1, 2, 3, 4, 5, 6},
+				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
+				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
 			},
-			expectedResult: adjacentToCheck{
-				adjacentCols: []int{1, 2},
-				adjacentRows: []int{1, 2},
+			expectOutput: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 1, 2, 3},
+				[]int{7, 8, 9, 1, 2, 3, 4, 5, 6},
+				[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
+				[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+				[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
 			},
+			expectUnique: true,
 		},
 		{
-			description: "second",
-			ep: position{
-				colNumber: 1,
-				rowNumber: 5,
-			},
-			reg: region{
-				minColNumber: 0,
-				maxColNumber: 2,
-				minRowNumber: 3,
-				maxRowNumber: 5,
-			},
-			expectedResult: adjacentToCheck{
-				adjacentCols: []int{0, 2},
-				adjacentRows: []int{3, 4},
+			description: "no number fits the last square",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 9},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 			},
+			expectErr: ErrNoSolution,
 		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			input := copyGrid(td.input)
+			output, unique, err := bruteForceGuess(newGeometry(3, 3), input)
+			assert.Equal(t, td.expectErr, err)
+			assert.Equal(t, td.expectOutput, output)
+			assert.Equal(t, td.expectUnique, unique)
+			assert.Equal(t, td.input, input)
+		})
+	}
+}
+
+func TestSolveGridNoSolution(t *testing.T) {
+	_, _, err := SolveGrid([][]int{
+		[]int{1, 2, 3, 4, 5, 6, 7, 8, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 9},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	})
+	assert.Equal(t, ErrNoSolution, err)
+}
+
+func TestSolveGridDoesNotModifyInput(t *testing.T) {
+	tt := []struct {
+		description string
+		input       [][]int
+		expectErr   bool
+	}{
 		{
-			description: "third",
-			ep: position{
-				colNumber: 7,
-				rowNumber: 8,
-			},
-			reg: region{
-				minColNumber: 6,
-				maxColNumber: 8,
-				minRowNumber: 6,
-				maxRowNumber: 8,
-			},
-			expectedResult: adjacentToCheck{
-				adjacentCols: []int{6, 8},
-				adjacentRows: []int{6, 7},
+			description: "solved",
+			input: [][]int{
+				[]int{2, 0, 7, 0, 0, 6, 0, 0, 0},
+				[]int{0, 0, 0, 0, 3, 0, 2, 0, 6},
+				[]int{0, 5, 6, 0, 0, 2, 0, 4, 1},
+				[]int{1, 0, 0, 3, 0, 8, 7, 6, 0},
+				[]int{6, 0, 9, 0, 0, 0, 1, 0, 8},
+				[]int{0, 7, 4, 6, 0, 5, 0, 0, 3},
+				[]int{5, 8, 0, 7, 0, 0, 4, 1, 0},
+				[]int{9, 0, 1, 0, 5, 0, 0, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 3, 0, 5},
 			},
 		},
 		{
-			description: "fourth",
-			ep: position{
-				colNumber: 8,
-				rowNumber: 8,
-			},
-			reg: region{
-				minColNumber: 6,
-				maxColNumber: 8,
-				minRowNumber: 6,
-				maxRowNumber: 8,
-			},
-			expectedResult: adjacentToCheck{
-				adjacentCols: []int{6, 7},
-				adjacentRows: []int{6, 7},
+			description: "no solution after filling squares",
+			input: [][]int{
+				[]int{1, 2, 3, 4, 5, 6, 7, 8, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 9},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 			},
+			expectErr: true,
 		},
 	}
 
 	for _, td := range tt {
 		t.Run(td.description, func(t *testing.T) {
-			res := adjacentRowsAndCols(td.reg, td.ep)
-			sort.Ints(res.adjacentCols)
-			sort.Ints(res.adjacentRows)
-			assert.Equal(t, td.expectedResult, res)
+			for _, engine := range []Engine{EngineLogical, EngineDLX} {
+				input := copyGrid(td.input)
+				_, _, err := SolveGrid(input, WithEngine(engine))
+				assert.Equal(t, td.expectErr, err != nil)
+				assert.Equal(t, td.input, input)
+			}
 		})
 	}
 }
 
+func TestSolveGridInPlace(t *testing.T) {
+	grid := [][]int{
+		[]int{0, 0, 0, 4, 5, 6, 7, 8, 9},
+		[]int{0, 0, 0, 7, 8, 9, 1, 2, 3},
+		[]int{0, 0, 0, 1, 2, 3, 4, 5, 6},
+		[]int{2, 3, 4, 5, 6, 7, 8, 9, 1},
+		[]int{5, 6, 7, 8, 9, 1, 2, 3, 4},
+		[]int{8, 9, 1, 2, 3, 4, 5, 6, 7},
+		[]int{3, 4, 5, 6, 7, 8, 9, 1, 2},
+		[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
+		[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	cg, err := SolveGridInPlace(grid)
+	require.Nil(t, err)
+	assert.Equal(t, CheckedGrid{Valid: true, Complete: true, Unique: true}, cg)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, grid[0])
+
+	unsolvable := [][]int{
+		[]int{1, 2, 3, 4, 5, 6, 7, 8, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 9},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	}
+	expect := copyGrid(unsolvable)
+	_, err = SolveGridInPlace(unsolvable)
+	assert.Equal(t, ErrNoSolution, err)
+	assert.Equal(t, expect, unsolvable)
+}
+
 func TestCheckGrid(t *testing.T) {
 	tt := []struct {
 		description  string
@@ -603,7 +646,11 @@ func TestCheckGrid(t *testing.T) {
 				[]int{6, 7, 8, 9, 1, 2, 3, 4, 5},
 				[]int{9, 1, 2, 3, 4, 5, 6, 7, 8},
 			},
-			expectReturn: CheckedGrid{Complete: false, Valid: false},
+			expectReturn: CheckedGrid{Complete: false, Valid: false, Conflicts: []Conflict{
+				{Unit: Unit{Kind: UnitRow, Index: 0}, Digit: 1, Cells: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}}},
+				{Unit: Unit{Kind: UnitColumn, Index: 1}, Digit: 1, Cells: []Cell{{Row: 0, Col: 1}, {Row: 8, Col: 1}}},
+				{Unit: Unit{Kind: UnitBox, Index: 0}, Digit: 1, Cells: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}}},
+			}},
 		},
 		{
 			description: "invalid, duplicate in column",
@@ -618,7 +665,11 @@ func TestCheckGrid(t *testing.T) {
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 			},
-			expectReturn: CheckedGrid{Complete: false, Valid: false},
+			expectReturn: CheckedGrid{Complete: false, Valid: false, Conflicts: []Conflict{
+				{Unit: Unit{Kind: UnitColumn, Index: 0}, Digit: 1, Cells: []Cell{{Row: 0, Col: 0}, {Row: 1, Col: 0}}},
+				{Unit: Unit{Kind: UnitColumn, Index: 2}, Digit: 2, Cells: []Cell{{Row: 2, Col: 2}, {Row: 3, Col: 2}}},
+				{Unit: Unit{Kind: UnitBox, Index: 0}, Digit: 1, Cells: []Cell{{Row: 0, Col: 0}, {Row: 1, Col: 0}}},
+			}},
 		},
 		{
 			description: "invalid, duplicate in top grid region",
@@ -633,7 +684,9 @@ func TestCheckGrid(t *testing.T) {
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 			},
-			expectReturn: CheckedGrid{Complete: false, Valid: false},
+			expectReturn: CheckedGrid{Complete: false, Valid: false, Conflicts: []Conflict{
+				{Unit: Unit{Kind: UnitBox, Index: 0}, Digit: 1, Cells: []Cell{{Row: 0, Col: 0}, {Row: 1, Col: 1}, {Row: 2, Col: 2}}},
+			}},
 		},
 		{
 			description: "invalid, duplicate in bottom grid region",
@@ -648,7 +701,9 @@ func TestCheckGrid(t *testing.T) {
 				[]int{0, 0, 0, 0, 0, 0, 0, 1, 0},
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 			},
-			expectReturn: CheckedGrid{Complete: false, Valid: false},
+			expectReturn: CheckedGrid{Complete: false, Valid: false, Conflicts: []Conflict{
+				{Unit: Unit{Kind: UnitBox, Index: 8}, Digit: 1, Cells: []Cell{{Row: 6, Col: 6}, {Row: 7, Col: 7}}},
+			}},
 		},
 		{
 			description: "invalid, duplicate in middle grid region",
@@ -663,6 +718,30 @@ func TestCheckGrid(t *testing.T) {
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
 			},
+			expectReturn: CheckedGrid{Complete: false, Valid: false, Conflicts: []Conflict{
+				{Unit: Unit{Kind: UnitBox, Index: 4}, Digit: 1, Cells: []Cell{{Row: 4, Col: 4}, {Row: 5, Col: 5}}},
+			}},
+		},
+		{
+			description: "invalid, short row",
+			input: [][]int{
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			expectReturn: CheckedGrid{Complete: false, Valid: false},
+		},
+		{
+			description: "invalid, missing rows",
+			input: [][]int{
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
 			expectReturn: CheckedGrid{Complete: false, Valid: false},
 		},
 	}
@@ -672,6 +751,80 @@ func TestCheckGrid(t *testing.T) {
 			cg := CheckGrid(td.input)
 			assert.Equal(t, td.expectReturn.Complete, cg.Complete)
 			assert.Equal(t, td.expectReturn.Valid, cg.Valid)
+			assert.Equal(t, td.expectReturn.Conflicts, cg.Conflicts)
+		})
+	}
+}
+
+// benchmarkGrids are the puzzles from TestSolveGridRealExamples
+var benchmarkGrids = []struct {
+	description string
+	input       [][]int
+}{
+	{
+		description: "one",
+		input: [][]int{
+			[]int{2, 0, 7, 0, 0, 6, 0, 0, 0},
+			[]int{0, 0, 0, 0, 3, 0, 2, 0, 6},
+			[]int{0, 5, 6, 0, 0, 2, 0, 4, 1},
+			[]int{1, 0, 0, 3, 0, 8, 7, 6, 0},
+			[]int{6, 0, 9, 0, 0, 0, 1, 0, 8},
+			[]int{0, 7, 4, 6, 0, 5, 0, 0, 3},
+			[]int{5, 8, 0, 7, 0, 0, 4, 1, 0},
+			[]int{9, 0, 1, 0, 5, 0, 0, 0, 0},
+			[]int{0, 0, 0, 1, 0, 0, 3, 0, 5},
+		},
+	},
+	{
+		description: "two",
+		input: [][]int{
+			[]int{3, 4, 2, 6, 9, 7, 5, 8, 1},
+			[]int{6, 1, 8, 5, 2, 4, 7, 9, 3},
+			[]int{5, 9, 7, 1, 8, 3, 4, 6, 2},
+			[]int{2, 7, 3, 4, 1, 0, 9, 5, 6},
+			[]int{1, 6, 4, 7, 5, 9, 0, 3, 0},
+			[]int{9, 8, 5, 0, 3, 0, 1, 0, 7},
+			[]int{8, 5, 9, 3, 7, 1, 6, 2, 4},
+			[]int{7, 0, 6, 0, 4, 5, 0, 1, 9},
+			[]int{4, 0, 1, 9, 6, 0, 0, 7, 5},
+		},
+	},
+	{
+		description: "hard, requires searching",
+		input: [][]int{
+			[]int{8, 0, 0, 0, 0, 0, 0, 0, 0},
+			[]int{0, 0, 3, 6, 0, 0, 0, 0, 0},
+			[]int{0, 7, 0, 0, 9, 0, 2, 0, 0},
+			[]int{0, 5, 0, 0, 0, 7, 0, 0, 0},
+			[]int{0, 0, 0, 0, 4, 5, 7, 0, 0},
+			[]int{0, 0, 0, 1, 0, 0, 0, 3, 0},
+			[]int{0, 0, 1, 0, 0, 0, 0, 6, 8},
+			[]int{0, 0, 8, 5, 0, 0, 0, 1, 0},
+			[]int{0, 9, 0, 0, 0, 0, 4, 0, 0},
+		},
+	},
+}
+
+func BenchmarkSolveGrid(b *testing.B) {
+	for _, bg := range benchmarkGrids {
+		b.Run(bg.description, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := SolveGrid(copyGrid(bg.input)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBruteForceGuess(b *testing.B) {
+	for _, bg := range benchmarkGrids {
+		b.Run(bg.description, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := bruteForceGuess(newGeometry(3, 3), bg.input); err != nil {
+					b.Fatal(err)
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,27 @@
+package soduku
+
+import (
+	"context"
+	"errors"
+)
+
+// EachSolution calls fn with every completion of the grid in turn, in the order the depth
+// first search finds them. Each grid passed to fn is a fresh copy that the caller may keep.
+// Returning false from fn stops the enumeration early. The given grid is not modified.
+//
+// Options give the layout of the grid, as for SolveGrid. If the context is cancelled before
+// every solution has been visited, EachSolution returns the context's error
+func EachSolution(ctx context.Context, grid [][]int, fn func([][]int) bool, opts ...Option) error {
+	geo, err := newOptions(opts).geometry(grid)
+	if err != nil {
+		return err
+	}
+	if cg := checkGrid(geo, grid); !cg.Valid {
+		return errors.New("the grid is invalid")
+	}
+
+	_, err = search(ctx, geo, grid, func(solution [][]int) bool {
+		return !fn(copyGrid(solution))
+	})
+	return err
+}
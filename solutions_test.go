@@ -0,0 +1,127 @@
+package soduku
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEachSolution(t *testing.T) {
+	tt := []struct {
+		description     string
+		input           [][]int
+		stopAfter       int
+		expectSolutions int
+	}{
+		{
+			description: "unique puzzle",
+			input: [][]int{
+				[]int{2, 0, 7, 0, 0, 6, 0, 0, 0},
+				[]int{0, 0, 0, 0, 3, 0, 2, 0, 6},
+				[]int{0, 5, 6, 0, 0, 2, 0, 4, 1},
+				[]int{1, 0, 0, 3, 0, 8, 7, 6, 0},
+				[]int{6, 0, 9, 0, 0, 0, 1, 0, 8},
+				[]int{0, 7, 4, 6, 0, 5, 0, 0, 3},
+				[]int{5, 8, 0, 7, 0, 0, 4, 1, 0},
+				[]int{9, 0, 1, 0, 5, 0, 0, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 3, 0, 5},
+			},
+			expectSolutions: 1,
+		},
+		{
+			description: "two solutions",
+			input: [][]int{
+				[]int{0, 0, 3, 4, 5, 6, 7, 8, 9},
+				[]int{4, 5, 6, 7, 8, 9, 0, 0, 3},
+				[]int{7, 8, 9, 0, 0, 3, 4, 5, 6},
+				[]int{0, 3, 4, 5, 6, 7, 8, 9, 0},
+				[]int{5, 6, 7, 8, 9, 0, 0, 3, 4},
+				[]int{8, 9, 0, 0, 3, 4, 5, 6, 7},
+				[]int{3, 4, 5, 6, 7, 8, 9, 0, 0},
+				[]int{6, 7, 8, 9, 0, 0, 3, 4, 5},
+				[]int{9, 0, 0, 3, 4, 5, 6, 7, 8},
+			},
+			expectSolutions: 2,
+		},
+		{
+			description: "stopped by the callback",
+			input: [][]int{
+				[]int{1, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 1, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 0, 0, 0},
+				[]int{0, 0, 1, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			stopAfter:       3,
+			expectSolutions: 3,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			input := copyGrid(td.input)
+			solutions := [][][]int{}
+			err := EachSolution(context.Background(), input, func(solution [][]int) bool {
+				solutions = append(solutions, solution)
+				return len(solutions) != td.stopAfter
+			})
+			require.Nil(t, err)
+			assert.Equal(t, td.input, input)
+			require.Len(t, solutions, td.expectSolutions)
+
+			for i, solution := range solutions {
+				assert.Equal(t, CheckedGrid{Valid: true, Complete: true}, CheckGrid(solution))
+				for j := 0; j < i; j++ {
+					assert.NotEqual(t, solutions[j], solution)
+				}
+			}
+		})
+	}
+}
+
+func TestEachSolutionCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	count := 0
+	err := EachSolution(ctx, [][]int{
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	}, func([][]int) bool {
+		count++
+		if count == 2 {
+			cancel()
+		}
+		return true
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestEachSolutionInvalidGrid(t *testing.T) {
+	err := EachSolution(context.Background(), [][]int{
+		[]int{1, 1, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	}, func([][]int) bool {
+		return true
+	})
+	assert.NotNil(t, err)
+}
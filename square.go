@@ -16,31 +16,41 @@ type position struct {
 	colNumber int
 }
 
-type region struct {
-	minColNumber int
-	maxColNumber int
-	minRowNumber int
-	maxRowNumber int
-}
-
-// allRegions are the 9 sections on the grid to make between 1 and 9
-var allRegions = []region{
-	region{minRowNumber: 0, maxRowNumber: 2, minColNumber: 0, maxColNumber: 2},
-	region{minRowNumber: 0, maxRowNumber: 2, minColNumber: 3, maxColNumber: 5},
-	region{minRowNumber: 0, maxRowNumber: 2, minColNumber: 6, maxColNumber: 8},
-	region{minRowNumber: 3, maxRowNumber: 5, minColNumber: 0, maxColNumber: 2},
-	region{minRowNumber: 3, maxRowNumber: 5, minColNumber: 3, maxColNumber: 5},
-	region{minRowNumber: 3, maxRowNumber: 5, minColNumber: 6, maxColNumber: 8},
-	region{minRowNumber: 6, maxRowNumber: 8, minColNumber: 0, maxColNumber: 2},
-	region{minRowNumber: 6, maxRowNumber: 8, minColNumber: 3, maxColNumber: 5},
-	region{minRowNumber: 6, maxRowNumber: 8, minColNumber: 6, maxColNumber: 8},
+// region is a set of squares that must hold each number once, a box of a classic grid or an
+// irregular piece of a jigsaw grid. The squares are in reading order
+type region []position
+
+// bounds returns the smallest rectangle holding the region, and whether the region fills it
+func (r region) bounds() (minRow, maxRow, minCol, maxCol int, rect bool) {
+	minRow, maxRow, minCol, maxCol = r[0].rowNumber, r[0].rowNumber, r[0].colNumber, r[0].colNumber
+	for _, pos := range r {
+		if pos.rowNumber < minRow {
+			minRow = pos.rowNumber
+		}
+		if pos.rowNumber > maxRow {
+			maxRow = pos.rowNumber
+		}
+		if pos.colNumber < minCol {
+			minCol = pos.colNumber
+		}
+		if pos.colNumber > maxCol {
+			maxCol = pos.colNumber
+		}
+	}
+	return minRow, maxRow, minCol, maxCol, (maxRow-minRow+1)*(maxCol-minCol+1) == len(r)
 }
 
-func NewSquares(grid [][]int) ([]*square, error) {
+// NewSquares returns a square for every empty position of the grid. Options give the layout
+// of the grid, as for SolveGrid
+func NewSquares(grid [][]int, opts ...Option) ([]*square, error) {
 	ss := []*square{}
+	geo, err := newOptions(opts).geometry(grid)
+	if err != nil {
+		return ss, err
+	}
 	poss := getEmptySquares(grid)
 	for _, pos := range poss {
-		s, err := NewSquare(grid, pos)
+		s, err := newSquare(geo, grid, pos)
 		if err != nil {
 			return ss, err
 		}
@@ -49,16 +59,26 @@ func NewSquares(grid [][]int) ([]*square, error) {
 	return ss, nil
 }
 
-func NewSquare(grid [][]int, pos position) (*square, error) {
+// NewSquare returns the square at the position with the numbers it could hold. Options give
+// the layout of the grid, as for SolveGrid
+func NewSquare(grid [][]int, pos position, opts ...Option) (*square, error) {
+	geo, err := newOptions(opts).geometry(grid)
+	if err != nil {
+		return &square{pos: pos}, err
+	}
+	return newSquare(geo, grid, pos)
+}
+
+func newSquare(geo *geometry, grid [][]int, pos position) (*square, error) {
 	s := &square{
 		pos: pos,
 	}
-	err := s.getRegion()
+	err := s.getRegion(geo)
 	if err != nil {
 		return s, err
 	}
 
-	if err := s.getPossibleNumbers(grid); err != nil {
+	if err := s.getPossibleNumbers(geo, grid); err != nil {
 		return s, err
 	}
 	return s, nil
@@ -84,68 +104,41 @@ func getEmptySquares(grid [][]int) []position {
 }
 
 // possibleNumbers returns the numbers that can possibly placed into a given position
-func (s *square) getPossibleNumbers(grid [][]int) error {
-	s.possibleNums = []int{}
-	possibleNumbers := map[int]bool{}
-	for i := 1; i <= 9; i++ {
-		possibleNumbers[i] = true
-	}
+func (s *square) getPossibleNumbers(geo *geometry, grid [][]int) error {
+	used := digitSet(0)
 
-	// check the row it is on
-	for col := 0; col <= 8; col++ {
-		possibleNumbers[grid[s.pos.rowNumber][col]] = false
+	// check every house it is in, its row, column and region
+	for _, h := range geo.housesOf(s.pos) {
+		for _, pos := range h.positions {
+			used |= 1 << uint(grid[pos.rowNumber][pos.colNumber])
+		}
 	}
 
-	// check the column it is in
-	for row := 0; row <= 8; row++ {
-		possibleNumbers[grid[row][s.pos.colNumber]] = false
-	}
+	possible := geo.digits &^ used
 
-	// Check the grid it is in
-	for row := s.reg.minRowNumber; row <= s.reg.maxRowNumber; row++ {
-		for col := s.reg.minColNumber; col <= s.reg.maxColNumber; col++ {
-			possibleNumbers[grid[row][col]] = false
+	// a square in a cage also has to complete one of the cage's combinations
+	if c := geo.cageOf[s.pos.rowNumber][s.pos.colNumber]; c >= 0 {
+		inCage := digitSet(0)
+		for _, pos := range geo.cages[c].positions {
+			if num := grid[pos.rowNumber][pos.colNumber]; num > 0 {
+				inCage |= 1 << uint(num)
+			}
 		}
+		possible &= geo.cages[c].allowed(inCage)
 	}
 
-	for num, stillPossible := range possibleNumbers {
-		if stillPossible {
-			s.possibleNums = append(s.possibleNums, num)
-		}
-	}
+	s.possibleNums = possible.numbers()
 	return nil
 }
 
 // getRegion returns he grid position that the position is in
-func (s *square) getRegion() error {
-	reg := region{}
-	switch {
-	case s.pos.rowNumber >= 0 && s.pos.rowNumber <= 2:
-		reg.minRowNumber = 0
-		reg.maxRowNumber = 2
-	case s.pos.rowNumber >= 3 && s.pos.rowNumber <= 5:
-		reg.minRowNumber = 3
-		reg.maxRowNumber = 5
-	case s.pos.rowNumber >= 6 && s.pos.rowNumber <= 8:
-		reg.minRowNumber = 6
-		reg.maxRowNumber = 8
-	default:
+func (s *square) getRegion(geo *geometry) error {
+	if s.pos.rowNumber < 0 || s.pos.rowNumber >= geo.size {
 		return fmt.Errorf("rowNumber %d is invalid", s.pos.rowNumber)
 	}
-
-	switch {
-	case s.pos.colNumber >= 0 && s.pos.colNumber <= 2:
-		reg.minColNumber = 0
-		reg.maxColNumber = 2
-	case s.pos.colNumber >= 3 && s.pos.colNumber <= 5:
-		reg.minColNumber = 3
-		reg.maxColNumber = 5
-	case s.pos.colNumber >= 6 && s.pos.colNumber <= 8:
-		reg.minColNumber = 6
-		reg.maxColNumber = 8
-	default:
+	if s.pos.colNumber < 0 || s.pos.colNumber >= geo.size {
 		return fmt.Errorf("colNumber %d is invalid", s.pos.colNumber)
 	}
-	s.reg = reg
+	s.reg = geo.regions[geo.regionNumber(s.pos.rowNumber, s.pos.colNumber)]
 	return nil
 }
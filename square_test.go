@@ -35,12 +35,7 @@ func TestNewSquare(t *testing.T) {
 			},
 			expectedSquare: square{
 				possibleNums: []int{1, 2, 3},
-				reg: region{
-					minColNumber: 0,
-					minRowNumber: 0,
-					maxColNumber: 2,
-					maxRowNumber: 2,
-				},
+				reg:          boxRegion(0, 2, 0, 2),
 			},
 		},
 	}
@@ -66,12 +61,7 @@ func TestGetRegion(t *testing.T) {
 				rowNumber: 0,
 				colNumber: 0,
 			},
-			expectedRegion: region{
-				minRowNumber: 0,
-				maxRowNumber: 2,
-				minColNumber: 0,
-				maxColNumber: 2,
-			},
+			expectedRegion: boxRegion(0, 2, 0, 2),
 		},
 		{
 			description: "top left two",
@@ -79,12 +69,7 @@ func TestGetRegion(t *testing.T) {
 				rowNumber: 1,
 				colNumber: 2,
 			},
-			expectedRegion: region{
-				minRowNumber: 0,
-				maxRowNumber: 2,
-				minColNumber: 0,
-				maxColNumber: 2,
-			},
+			expectedRegion: boxRegion(0, 2, 0, 2),
 		},
 		{
 			description: "top right one",
@@ -92,12 +77,7 @@ func TestGetRegion(t *testing.T) {
 				rowNumber: 1,
 				colNumber: 7,
 			},
-			expectedRegion: region{
-				minRowNumber: 0,
-				maxRowNumber: 2,
-				minColNumber: 6,
-				maxColNumber: 8,
-			},
+			expectedRegion: boxRegion(0, 2, 6, 8),
 		},
 		{
 			description: "bottom right one",
@@ -105,12 +85,7 @@ func TestGetRegion(t *testing.T) {
 				rowNumber: 8,
 				colNumber: 7,
 			},
-			expectedRegion: region{
-				minRowNumber: 6,
-				maxRowNumber: 8,
-				minColNumber: 6,
-				maxColNumber: 8,
-			},
+			expectedRegion: boxRegion(6, 8, 6, 8),
 		},
 		{
 			description: "middle one",
@@ -118,12 +93,7 @@ func TestGetRegion(t *testing.T) {
 				rowNumber: 3,
 				colNumber: 4,
 			},
-			expectedRegion: region{
-				minRowNumber: 3,
-				maxRowNumber: 5,
-				minColNumber: 3,
-				maxColNumber: 5,
-			},
+			expectedRegion: boxRegion(3, 5, 3, 5),
 		},
 	}
 	for _, td := range tt {
@@ -131,7 +101,7 @@ func TestGetRegion(t *testing.T) {
 			s := square{
 				pos: td.pos,
 			}
-			err := s.getRegion()
+			err := s.getRegion(newGeometry(3, 3))
 			require.Nil(t, err)
 			assert.Equal(t, td.expectedRegion, s.reg)
 		})
@@ -248,9 +218,9 @@ func TestGetPossibleNumbers(t *testing.T) {
 			s := &square{
 				pos: td.pos,
 			}
-			err := s.getRegion()
+			err := s.getRegion(newGeometry(3, 3))
 			assert.Nil(t, err)
-			assert.Nil(t, s.getPossibleNumbers(td.input))
+			assert.Nil(t, s.getPossibleNumbers(newGeometry(3, 3), td.input))
 			sort.Ints(s.possibleNums)
 			assert.Equal(t, td.expectedOutput, s.possibleNums)
 
@@ -331,3 +301,14 @@ func TestGetEmptySquares(t *testing.T) {
 		})
 	}
 }
+
+// boxRegion returns the rectangular region between the rows and columns
+func boxRegion(minRow, maxRow, minCol, maxCol int) region {
+	reg := region{}
+	for row := minRow; row <= maxRow; row++ {
+		for col := minCol; col <= maxCol; col++ {
+			reg = append(reg, position{rowNumber: row, colNumber: col})
+		}
+	}
+	return reg
+}
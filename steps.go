@@ -0,0 +1,137 @@
+package soduku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Technique names the reasoning used to place a number
+type Technique string
+
+const (
+	// NakedSingle places the only number left for a square once its row, column and box are
+	// taken into account
+	NakedSingle Technique = "naked single"
+	// HiddenSingle places a number that has only one possible square left in a row, column or
+	// box
+	HiddenSingle Technique = "hidden single"
+	// Search places a number found by the depth first search rather than by logic
+	Search Technique = "search"
+	// GuessRequired is returned by Hint when no logical step applies, the number given is
+	// taken from the solution
+	GuessRequired Technique = "guess required"
+)
+
+// Step is a single deduction made while solving. A step either places Digit in Cell, or
+// when Digit is 0, removes the candidates in Eliminations. Reasons holds the squares that
+// justify the step, it is empty for numbers found by searching
+type Step struct {
+	Technique    Technique   `json:"technique"`
+	Cell         Cell        `json:"cell"`
+	Digit        int         `json:"digit"`
+	Eliminations []Candidate `json:"eliminations,omitempty"`
+	Reasons      []Cell      `json:"reasons,omitempty"`
+	// BaseSets and CoverSets are only set for fish. The number has to go in the base sets,
+	// which are filled by the cover sets, so it is removed from the rest of the cover sets
+	BaseSets  []Unit `json:"baseSets,omitempty"`
+	CoverSets []Unit `json:"coverSets,omitempty"`
+	// Pivot and Pincers are only set for wings. The pincers are the squares an elimination has
+	// to see, a w-wing has no pivot
+	Pivot   *Cell  `json:"pivot,omitempty"`
+	Pincers []Cell `json:"pincers,omitempty"`
+	// Chain is only set for coloring and chains, it holds the candidates and links that
+	// justify the eliminations
+	Chain *Chain `json:"chain,omitempty"`
+	// Prerequisites is only set by Hint, it holds the elimination steps that have to be made
+	// before this number can be placed
+	Prerequisites []Step `json:"prerequisites,omitempty"`
+}
+
+// IsPlacement returns whether the step places a number, rather than removing candidates
+func (s Step) IsPlacement() bool {
+	return s.Digit != 0
+}
+
+func (s Step) String() string {
+	line := fmt.Sprintf("%s: %s = %d", s.Technique, s.Cell, s.Digit)
+	if !s.IsPlacement() {
+		elims := make([]string, 0, len(s.Eliminations))
+		for _, e := range s.Eliminations {
+			elims = append(elims, fmt.Sprintf("%s<>%d", e.Cell, e.Digit))
+		}
+		line = fmt.Sprintf("%s: %s", s.Technique, strings.Join(elims, ", "))
+	}
+	if len(s.BaseSets) > 0 {
+		line = fmt.Sprintf("%s [%s / %s]", line, joinUnits(s.BaseSets), joinUnits(s.CoverSets))
+	}
+	if len(s.Pincers) > 0 {
+		line = fmt.Sprintf("%s [%s]", line, wingCells(s.Pivot, s.Pincers))
+	}
+	if s.Chain != nil {
+		line = fmt.Sprintf("%s [%s]", line, s.Chain)
+	}
+	if len(s.Reasons) == 0 {
+		return line
+	}
+	reasons := make([]string, 0, len(s.Reasons))
+	for _, c := range s.Reasons {
+		reasons = append(reasons, c.String())
+	}
+	return fmt.Sprintf("%s (%s)", line, strings.Join(reasons, ", "))
+}
+
+// joinUnits lists the units separated by commas
+func joinUnits(units []Unit) string {
+	names := make([]string, 0, len(units))
+	for _, u := range units {
+		names = append(names, u.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+// wingCells describes the pivot, when there is one, and the pincers of a wing
+func wingCells(pivot *Cell, pincers []Cell) string {
+	names := make([]string, 0, len(pincers))
+	for _, c := range pincers {
+		names = append(names, c.String())
+	}
+	line := "pincers " + strings.Join(names, ", ")
+	if pivot != nil {
+		line = fmt.Sprintf("pivot %s, %s", pivot, line)
+	}
+	return line
+}
+
+// SolveLog is the ordered list of steps taken to solve a grid. It is filled in by SolveGrid
+// when passed with WithSolveLog, and encodes to JSON as {"steps": [...]}
+type SolveLog struct {
+	Steps []Step `json:"steps"`
+}
+
+// add appends a step, it is safe to call on a nil log
+func (l *SolveLog) add(s Step) {
+	if l == nil {
+		return
+	}
+	l.Steps = append(l.Steps, s)
+}
+
+// String renders the log as numbered lines of text, one per step
+func (l *SolveLog) String() string {
+	var sb strings.Builder
+	for i, s := range l.Steps {
+		fmt.Fprintf(&sb, "%d. %s\n", i+1, s)
+	}
+	return sb.String()
+}
+
+// recordSearch adds a search step for every square that is empty in grid but filled in solved
+func recordSearch(log *SolveLog, grid, solved [][]int) {
+	for _, pos := range getEmptySquares(grid) {
+		log.add(Step{
+			Technique: Search,
+			Cell:      Cell{Row: pos.rowNumber, Col: pos.colNumber},
+			Digit:     solved[pos.rowNumber][pos.colNumber],
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package soduku
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveLog(t *testing.T) {
+	tt := []struct {
+		description     string
+		input           [][]int
+		expectSearching bool
+		expectTechnique Technique
+	}{
+		{
+			description: "real example one",
+			input: [][]int{
+				[]int{2, 0, 7, 0, 0, 6, 0, 0, 0},
+				[]int{0, 0, 0, 0, 3, 0, 2, 0, 6},
+				[]int{0, 5, 6, 0, 0, 2, 0, 4, 1},
+				[]int{1, 0, 0, 3, 0, 8, 7, 6, 0},
+				[]int{6, 0, 9, 0, 0, 0, 1, 0, 8},
+				[]int{0, 7, 4, 6, 0, 5, 0, 0, 3},
+				[]int{5, 8, 0, 7, 0, 0, 4, 1, 0},
+				[]int{9, 0, 1, 0, 5, 0, 0, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 3, 0, 5},
+			},
+			expectTechnique: NakedSingle,
+		},
+		{
+			description: "needs hidden singles",
+			input: [][]int{
+				[]int{0, 0, 0, 0, 0, 0, 9, 0, 7},
+				[]int{0, 0, 0, 4, 2, 0, 1, 8, 0},
+				[]int{0, 0, 0, 7, 0, 5, 0, 2, 6},
+				[]int{1, 0, 0, 9, 0, 4, 0, 0, 0},
+				[]int{0, 5, 0, 0, 0, 0, 0, 4, 0},
+				[]int{0, 0, 0, 5, 0, 7, 0, 0, 9},
+				[]int{9, 2, 0, 1, 0, 8, 0, 0, 0},
+				[]int{0, 3, 4, 0, 5, 9, 0, 0, 0},
+				[]int{5, 0, 7, 0, 0, 0, 0, 0, 0},
+			},
+			expectTechnique: HiddenSingle,
+		},
+		{
+			description: "hard, requires searching",
+			input: [][]int{
+				[]int{8, 0, 0, 0, 0, 0, 0, 0, 0},
+				[]int{0, 0, 3, 6, 0, 0, 0, 0, 0},
+				[]int{0, 7, 0, 0, 9, 0, 2, 0, 0},
+				[]int{0, 5, 0, 0, 0, 7, 0, 0, 0},
+				[]int{0, 0, 0, 0, 4, 5, 7, 0, 0},
+				[]int{0, 0, 0, 1, 0, 0, 0, 3, 0},
+				[]int{0, 0, 1, 0, 0, 0, 0, 6, 8},
+				[]int{0, 0, 8, 5, 0, 0, 0, 1, 0},
+				[]int{0, 9, 0, 0, 0, 0, 4, 0, 0},
+			},
+			expectSearching: true,
+			expectTechnique: Search,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			log := &SolveLog{}
+			solved, _, err := SolveGrid(td.input, WithSolveLog(log))
+			require.Nil(t, err)
+
+			techniques := replaySolveLog(t, td.input, solved, log)
+			assert.Equal(t, td.expectSearching, techniques[Search] > 0)
+			assert.True(t, techniques[td.expectTechnique] > 0)
+		})
+	}
+}
+
+// replaySolveLog applies the log to the input, checking that every step agrees with the
+// solution. It returns how many times each technique was used
+func replaySolveLog(t *testing.T, input, solved [][]int, log *SolveLog) map[Technique]int {
+	replay := copyGrid(input)
+	techniques := map[Technique]int{}
+	eliminated := false
+	for _, step := range log.Steps {
+		techniques[step.Technique]++
+		if !step.IsPlacement() {
+			// Eliminations must never remove the number from the solution
+			require.NotEmpty(t, step.Eliminations, step.String())
+			for _, e := range step.Eliminations {
+				assert.Equal(t, 0, replay[e.Row][e.Col], step.String())
+				assert.NotEqual(t, solved[e.Row][e.Col], e.Digit, step.String())
+			}
+			eliminated = true
+			continue
+		}
+
+		// Placements must put the solution's number into an empty square
+		assert.Equal(t, 0, replay[step.Cell.Row][step.Cell.Col], step.String())
+		assert.Equal(t, solved[step.Cell.Row][step.Cell.Col], step.Digit, step.String())
+		if step.Technique == NakedSingle && !eliminated {
+			// Until candidates are eliminated, the reasons account for every other number
+			seen := map[int]bool{step.Digit: true}
+			for _, c := range step.Reasons {
+				assert.NotEqual(t, 0, replay[c.Row][c.Col], step.String())
+				seen[replay[c.Row][c.Col]] = true
+			}
+			assert.Len(t, seen, len(input), step.String())
+		}
+		replay[step.Cell.Row][step.Cell.Col] = step.Digit
+	}
+	assert.Equal(t, solved, replay)
+	return techniques
+}
+
+func TestSolveLogRender(t *testing.T) {
+	log := &SolveLog{Steps: []Step{
+		{
+			Technique: NakedSingle,
+			Cell:      Cell{Row: 0, Col: 0},
+			Digit:     1,
+			Reasons:   []Cell{{Row: 0, Col: 1}, {Row: 1, Col: 0}},
+		},
+		{
+			Technique: Search,
+			Cell:      Cell{Row: 8, Col: 8},
+			Digit:     9,
+		},
+	}}
+	assert.Equal(t, "1. naked single: r1c1 = 1 (r1c2, r2c1)\n2. search: r9c9 = 9\n", log.String())
+
+	b, err := json.Marshal(log)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"steps": [
+		{
+			"technique": "naked single",
+			"cell": {"row": 0, "col": 0},
+			"digit": 1,
+			"reasons": [{"row": 0, "col": 1}, {"row": 1, "col": 0}]
+		},
+		{
+			"technique": "search",
+			"cell": {"row": 8, "col": 8},
+			"digit": 9
+		}
+	]}`, string(b))
+}
+
+func TestHiddenSingle(t *testing.T) {
+	// 1 can only go in the top right square of the first row, as the other empty squares in
+	// the row see a 1 in their column
+	grid := [][]int{
+		[]int{0, 0, 0, 2, 3, 4, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{1, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 1, 0, 0},
+		[]int{0, 1, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 1, 0},
+		[]int{0, 0, 1, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	}
+	b := newCandidateBoard(newGeometry(3, 3), grid)
+	assert.Equal(t, &Step{
+		Technique: HiddenSingle,
+		Cell:      Cell{Row: 0, Col: 8},
+		Digit:     1,
+		Reasons: []Cell{
+			{Row: 3, Col: 0},
+			{Row: 5, Col: 1},
+			{Row: 7, Col: 2},
+			{Row: 4, Col: 6},
+			{Row: 6, Col: 7},
+		},
+	}, hiddenSingle(b, b.geo.houses[0]))
+
+	// Nothing is forced in the second row
+	assert.Nil(t, hiddenSingle(b, b.geo.houses[1]))
+}
@@ -0,0 +1,93 @@
+package soduku
+
+// Strategy is a way of making logical progress on a grid. Find looks at the candidate board
+// and returns a step that either places a number or removes candidates, or nil if it cannot
+// make any progress. Find must not change the board, SolveGrid applies the step itself.
+//
+// Steps that would not change the board are skipped, so a strategy cannot stall the solve
+type Strategy interface {
+	Technique() Technique
+	Find(b *CandidateBoard) *Step
+}
+
+// NewStrategy returns a strategy for the technique that calls find
+func NewStrategy(t Technique, find func(b *CandidateBoard) *Step) Strategy {
+	return logicalTechnique{name: t, find: find}
+}
+
+// logicalTechnique is a named way of finding steps on the board. Techniques marked uniqueness
+// are only sound when the puzzle has a single solution
+type logicalTechnique struct {
+	name       Technique
+	find       func(b *CandidateBoard) *Step
+	uniqueness bool
+}
+
+// Technique returns the name of the technique
+func (t logicalTechnique) Technique() Technique {
+	return t.name
+}
+
+// Find returns the first step the technique can take on the board
+func (t logicalTechnique) Find(b *CandidateBoard) *Step {
+	return t.find(b)
+}
+
+// logicalTechniques are the built in strategies, from the easiest to the hardest
+var logicalTechniques = []logicalTechnique{
+	{name: NakedSingle, find: findNakedSingle},
+	{name: HiddenSingle, find: findHiddenSingle},
+	{name: CageSum, find: findCageSum},
+	{name: PointingCandidates, find: findPointing},
+	{name: BoxLineReduction, find: findBoxLineReduction},
+	{name: NakedPair, find: findNakedSubset(2)},
+	{name: HiddenPair, find: findHiddenSubset(2)},
+	{name: NakedTriple, find: findNakedSubset(3)},
+	{name: HiddenTriple, find: findHiddenSubset(3)},
+	{name: NakedQuad, find: findNakedSubset(4)},
+	{name: HiddenQuad, find: findHiddenSubset(4)},
+	{name: XWing, find: findFish(2)},
+	{name: Swordfish, find: findFish(3)},
+	{name: XYWing, find: findXYWing},
+	{name: XYZWing, find: findXYZWing},
+	{name: WWing, find: findWWing},
+	{name: UniqueRectangle1, find: findUniqueRectangle(1), uniqueness: true},
+	{name: UniqueRectangle2, find: findUniqueRectangle(2), uniqueness: true},
+	{name: UniqueRectangle3, find: findUniqueRectangle(3), uniqueness: true},
+	{name: UniqueRectangle4, find: findUniqueRectangle(4), uniqueness: true},
+	{name: BUGPlusOne, find: findBUGPlusOne, uniqueness: true},
+	{name: Jellyfish, find: findFish(4)},
+	{name: SimpleColoring, find: findSimpleColoring},
+	{name: XChain, find: findXChain},
+	{name: XYChain, find: findXYChain},
+	{name: AIC, find: findAIC},
+}
+
+// DefaultStrategies returns the built in strategies in the order SolveGrid tries them, from
+// the easiest to the hardest. The list is a new slice each time, so it can be changed and
+// passed to WithStrategies. Unique rectangles and BUG+1 are included, but are skipped unless
+// WithUniquenessTechniques is given
+func DefaultStrategies() []Strategy {
+	strategies := make([]Strategy, 0, len(logicalTechniques))
+	for _, t := range logicalTechniques {
+		strategies = append(strategies, t)
+	}
+	return strategies
+}
+
+// nextStep returns the first step found by the strategies, tried in order, or nil if there is
+// no logical step left. The board is not changed
+func nextStep(b *CandidateBoard, strategies []Strategy) *Step {
+	for _, s := range strategies {
+		if step := s.Find(b); step != nil && b.progress(step) {
+			return step
+		}
+	}
+	return nil
+}
+
+// needsUniqueness returns whether the strategy is only sound for puzzles with one solution
+func needsUniqueness(s Strategy) bool {
+	t, ok := s.(logicalTechnique)
+	return ok && t.uniqueness
+}
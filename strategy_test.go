@@ -0,0 +1,115 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readmeGrid is the example from the README, it only needs naked and hidden singles
+const readmeGrid = "207006000000030206056002041100308760609000108074605003580700410901050000000100305"
+
+func TestDefaultStrategies(t *testing.T) {
+	strategies := DefaultStrategies()
+	require.Len(t, strategies, len(logicalTechniques))
+	assert.Equal(t, NakedSingle, strategies[0].Technique())
+	assert.Equal(t, HiddenSingle, strategies[1].Technique())
+	assert.Equal(t, AIC, strategies[len(strategies)-1].Technique())
+
+	// Changing the list does not change the defaults
+	strategies[0] = nil
+	assert.Equal(t, NakedSingle, DefaultStrategies()[0].Technique())
+}
+
+func TestWithStrategies(t *testing.T) {
+	// An in house naked single, written against the exported board only
+	const inHouse Technique = "in house single"
+	single := NewStrategy(inHouse, func(b *CandidateBoard) *Step {
+		for _, u := range b.Units() {
+			for _, c := range b.Cells(u) {
+				if cands := b.Candidates(c); b.Get(c) == 0 && len(cands) == 1 {
+					return &Step{Technique: inHouse, Cell: c, Digit: cands[0]}
+				}
+			}
+		}
+		return nil
+	})
+
+	// A strategy that never makes progress must not stall the solve
+	stuck := NewStrategy("stuck", func(b *CandidateBoard) *Step {
+		return &Step{Technique: "stuck", Eliminations: []Candidate{{Cell: Cell{Row: 9, Col: 9}, Digit: 1}}}
+	})
+
+	// Nor one placing a number its row already holds, r1c1 is 2
+	wrong := NewStrategy("wrong", func(b *CandidateBoard) *Step {
+		return &Step{Technique: "wrong", Cell: Cell{Row: 0, Col: 1}, Digit: 2}
+	})
+
+	tt := []struct {
+		description      string
+		strategies       []Strategy
+		expectTechniques []Technique
+	}{
+		{
+			description:      "in house strategy",
+			strategies:       []Strategy{stuck, wrong, single},
+			expectTechniques: []Technique{inHouse},
+		},
+		{
+			description:      "in house strategy with the defaults",
+			strategies:       append(DefaultStrategies(), single),
+			expectTechniques: []Technique{NakedSingle},
+		},
+		{
+			description:      "no strategies",
+			strategies:       []Strategy{},
+			expectTechniques: []Technique{Search},
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(readmeGrid)
+			require.Nil(t, err)
+
+			log := &SolveLog{}
+			solved, cg, err := SolveGrid(g.Rows(), WithSolveLog(log), WithStrategies(td.strategies...))
+			require.Nil(t, err)
+			assert.True(t, cg.Complete)
+
+			techniques := replaySolveLog(t, g.Rows(), solved, log)
+			for _, tech := range td.expectTechniques {
+				assert.True(t, techniques[tech] > 0)
+			}
+			assert.Equal(t, 0, techniques["stuck"])
+			assert.Equal(t, 0, techniques["wrong"])
+
+			step, err := Hint(g.Rows(), WithStrategies(td.strategies...))
+			require.Nil(t, err)
+			assert.NotEqual(t, Technique("wrong"), step.Technique)
+		})
+	}
+}
+
+func TestNewCandidateBoard(t *testing.T) {
+	_, err := NewCandidateBoard([][]int{{1}})
+	assert.IsType(t, &ShapeError{}, err)
+
+	g, err := ParseGrid(readmeGrid)
+	require.Nil(t, err)
+	b, err := NewCandidateBoard(g.Rows())
+	require.Nil(t, err)
+
+	assert.Equal(t, 2, b.Get(Cell{Row: 0, Col: 0}))
+	assert.Empty(t, b.Candidates(Cell{Row: 0, Col: 0}))
+	assert.Equal(t, []int{1, 3, 4, 9}, b.Candidates(Cell{Row: 0, Col: 1}))
+	assert.Len(t, b.Units(), 27)
+	assert.Equal(t, Unit{Kind: UnitBox, Index: 8}, b.Units()[26])
+	assert.Equal(t, []Cell{{Row: 0, Col: 3}, {Row: 1, Col: 3}, {Row: 2, Col: 3}}, b.Cells(Unit{Kind: UnitColumn, Index: 3})[:3])
+	assert.Nil(t, b.Cells(Unit{Kind: UnitBox, Index: 9}))
+
+	// The board works on a copy of the grid
+	b.apply(&Step{Cell: Cell{Row: 0, Col: 1}, Digit: 1})
+	assert.Equal(t, 0, g.Rows()[0][1])
+}
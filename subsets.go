@@ -0,0 +1,200 @@
+package soduku
+
+const (
+	// NakedPair removes two numbers from a house when two of its squares only allow those two
+	NakedPair Technique = "naked pair"
+	// NakedTriple is a naked pair with three squares and three numbers
+	NakedTriple Technique = "naked triple"
+	// NakedQuad is a naked pair with four squares and four numbers
+	NakedQuad Technique = "naked quad"
+	// HiddenPair removes the other candidates from two squares when two numbers of a house can
+	// only go in those two squares
+	HiddenPair Technique = "hidden pair"
+	// HiddenTriple is a hidden pair with three squares and three numbers
+	HiddenTriple Technique = "hidden triple"
+	// HiddenQuad is a hidden pair with four squares and four numbers
+	HiddenQuad Technique = "hidden quad"
+)
+
+// nakedSubsets and hiddenSubsets map the size of a subset to its technique
+var (
+	nakedSubsets  = map[int]Technique{2: NakedPair, 3: NakedTriple, 4: NakedQuad}
+	hiddenSubsets = map[int]Technique{2: HiddenPair, 3: HiddenTriple, 4: HiddenQuad}
+)
+
+// findNakedSubset returns a technique finding the first naked subset of the size in any house
+func findNakedSubset(size int) func(b *CandidateBoard) *Step {
+	return func(b *CandidateBoard) *Step {
+		for _, h := range b.geo.houses {
+			if step := nakedSubset(b, h, size); step != nil {
+				return step
+			}
+		}
+		return nil
+	}
+}
+
+// findHiddenSubset returns a technique finding the first hidden subset of the size in any house
+func findHiddenSubset(size int) func(b *CandidateBoard) *Step {
+	return func(b *CandidateBoard) *Step {
+		for _, h := range b.geo.houses {
+			if step := hiddenSubset(b, h, size); step != nil {
+				return step
+			}
+		}
+		return nil
+	}
+}
+
+// nakedSubset looks for size empty squares of the house that between them allow only size
+// numbers. Those numbers have to go in those squares, so they are removed from every other
+// square of the house. It returns nil if the subset would not remove anything
+func nakedSubset(b *CandidateBoard, h house, size int) *Step {
+	empty := emptyPositions(b, h)
+	var step *Step
+	eachCombination(len(empty), size, func(picked []int) bool {
+		union := digitSet(0)
+		for _, i := range picked {
+			union |= b.candidates(empty[i])
+		}
+		if union.count() != size {
+			return false
+		}
+
+		subset := make([]position, 0, size)
+		for _, i := range picked {
+			subset = append(subset, empty[i])
+		}
+		elims := []Candidate{}
+		for _, pos := range empty {
+			if containsPosition(subset, pos) {
+				continue
+			}
+			elims = append(elims, candidatesOf(pos, b.candidates(pos)&union)...)
+		}
+		if len(elims) == 0 {
+			return false
+		}
+		step = &Step{
+			Technique:    nakedSubsets[size],
+			Eliminations: elims,
+			Reasons:      cellsOf(subset),
+		}
+		return true
+	})
+	return step
+}
+
+// hiddenSubset looks for size numbers that can only go in the same size squares of the house.
+// Those squares have to hold those numbers, so every other candidate is removed from them.
+// It returns nil if the subset would not remove anything
+func hiddenSubset(b *CandidateBoard, h house, size int) *Step {
+	empty := emptyPositions(b, h)
+	missing := digitSet(0)
+	for _, pos := range empty {
+		missing |= b.candidates(pos)
+	}
+	nums := missing.numbers()
+
+	var step *Step
+	eachCombination(len(nums), size, func(picked []int) bool {
+		subsetNums := digitSet(0)
+		for _, i := range picked {
+			subsetNums |= 1 << uint(nums[i])
+		}
+
+		subset := []position{}
+		for _, pos := range empty {
+			if b.candidates(pos)&subsetNums != 0 {
+				subset = append(subset, pos)
+			}
+		}
+		if len(subset) != size {
+			return false
+		}
+
+		elims := []Candidate{}
+		for _, pos := range subset {
+			elims = append(elims, candidatesOf(pos, b.candidates(pos)&^subsetNums)...)
+		}
+		if len(elims) == 0 {
+			return false
+		}
+		step = &Step{
+			Technique:    hiddenSubsets[size],
+			Eliminations: elims,
+			Reasons:      cellsOf(subset),
+		}
+		return true
+	})
+	return step
+}
+
+// emptyPositions returns the empty squares of the house
+func emptyPositions(b *CandidateBoard, h house) []position {
+	empty := []position{}
+	for _, pos := range h.positions {
+		if b.grid[pos.rowNumber][pos.colNumber] == 0 {
+			empty = append(empty, pos)
+		}
+	}
+	return empty
+}
+
+// eachCombination calls fn with every way of picking k of the indexes 0 to n-1, in
+// lexicographic order. Returning true from fn stops the iteration
+func eachCombination(n, k int, fn func([]int) bool) bool {
+	if k > n || k <= 0 {
+		return false
+	}
+	picked := make([]int, k)
+	for i := range picked {
+		picked[i] = i
+	}
+	for {
+		if fn(picked) {
+			return true
+		}
+
+		// Advance the rightmost index that still has room to move
+		i := k - 1
+		for i >= 0 && picked[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return false
+		}
+		picked[i]++
+		for j := i + 1; j < k; j++ {
+			picked[j] = picked[j-1] + 1
+		}
+	}
+}
+
+// candidatesOf returns a candidate at the position for every number in d
+func candidatesOf(pos position, d digitSet) []Candidate {
+	cs := []Candidate{}
+	for _, num := range d.numbers() {
+		cs = append(cs, Candidate{Cell: Cell{Row: pos.rowNumber, Col: pos.colNumber}, Digit: num})
+	}
+	return cs
+}
+
+// cellsOf converts positions to cells
+func cellsOf(poss []position) []Cell {
+	cells := make([]Cell, 0, len(poss))
+	for _, pos := range poss {
+		cells = append(cells, Cell{Row: pos.rowNumber, Col: pos.colNumber})
+	}
+	return cells
+}
+
+// containsPosition returns whether pos is in poss
+func containsPosition(poss []position, pos position) bool {
+	for _, p := range poss {
+		if p == pos {
+			return true
+		}
+	}
+	return false
+}
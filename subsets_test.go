@@ -0,0 +1,132 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveGridSubsets(t *testing.T) {
+	tt := []struct {
+		description     string
+		input           string
+		expectTechnique Technique
+	}{
+		{
+			description:     "naked pair",
+			input:           "020950000107000000805002007000300410001400902040000070000006000600008000010000053",
+			expectTechnique: NakedPair,
+		},
+		{
+			description:     "hidden pair",
+			input:           "005000078870000009000006520090500000300270400020010000900040010030001060080000090",
+			expectTechnique: HiddenPair,
+		},
+		{
+			description:     "naked triple",
+			input:           "004910000000070090600200403810030700005001000400000002000500000237000089000000010",
+			expectTechnique: NakedTriple,
+		},
+		{
+			description:     "hidden triple",
+			input:           "000000700300009002000601000120980030070500400950000000090300800000007004400010020",
+			expectTechnique: HiddenTriple,
+		},
+		{
+			description:     "naked quad",
+			input:           "000000507000019008040300900600004000207108006000000800890040250000090000005200300",
+			expectTechnique: NakedQuad,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+
+			log := &SolveLog{}
+			solved, _, err := SolveGrid(g.Rows(), WithSolveLog(log))
+			require.Nil(t, err)
+
+			techniques := replaySolveLog(t, g.Rows(), solved, log)
+			assert.True(t, techniques[td.expectTechnique] > 0)
+			assert.Equal(t, 0, techniques[Search])
+		})
+	}
+}
+
+func TestNakedSubset(t *testing.T) {
+	// In the first row only 1, 2, 3, 4 and 5 are missing. The squares in columns 0 and 1 can
+	// only hold 1 or 2, so neither number can go anywhere else in the row
+	grid := [][]int{
+		[]int{0, 0, 0, 0, 0, 6, 7, 8, 9},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{3, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{4, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{5, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 3, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 4, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 5, 0, 0, 0, 0, 0, 0, 0},
+	}
+	b := newCandidateBoard(newGeometry(3, 3), grid)
+	assert.Nil(t, nakedSubset(b, b.geo.houses[0], 3))
+	assert.Equal(t, &Step{
+		Technique: NakedPair,
+		Eliminations: []Candidate{
+			{Cell: Cell{Row: 0, Col: 2}, Digit: 1},
+			{Cell: Cell{Row: 0, Col: 2}, Digit: 2},
+			{Cell: Cell{Row: 0, Col: 3}, Digit: 1},
+			{Cell: Cell{Row: 0, Col: 3}, Digit: 2},
+			{Cell: Cell{Row: 0, Col: 4}, Digit: 1},
+			{Cell: Cell{Row: 0, Col: 4}, Digit: 2},
+		},
+		Reasons: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}},
+	}, nakedSubset(b, b.geo.houses[0], 2))
+}
+
+func TestHiddenSubset(t *testing.T) {
+	// 1 and 2 are kept out of the first row's last seven squares by the columns, so they have
+	// to go in columns 0 and 1 and every other candidate there can be removed
+	grid := [][]int{
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]int{0, 0, 1, 2, 0, 0, 0, 0, 0},
+		[]int{0, 0, 0, 0, 1, 2, 0, 0, 0},
+		[]int{0, 0, 2, 0, 0, 0, 1, 0, 0},
+		[]int{0, 0, 0, 1, 0, 0, 0, 2, 0},
+		[]int{0, 0, 0, 0, 2, 1, 0, 0, 0},
+		[]int{0, 0, 0, 0, 0, 0, 2, 1, 0},
+	}
+	b := newCandidateBoard(newGeometry(3, 3), grid)
+	// The last column still allows 1 and 2, remove them by hand
+	b.apply(&Step{Eliminations: []Candidate{
+		{Cell: Cell{Row: 0, Col: 8}, Digit: 1},
+		{Cell: Cell{Row: 0, Col: 8}, Digit: 2},
+	}})
+
+	step := hiddenSubset(b, b.geo.houses[0], 2)
+	require.NotNil(t, step)
+	assert.Equal(t, HiddenPair, step.Technique)
+	assert.Equal(t, []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}}, step.Reasons)
+	assert.Len(t, step.Eliminations, 14)
+}
+
+func TestEachCombination(t *testing.T) {
+	combinations := [][]int{}
+	eachCombination(4, 2, func(picked []int) bool {
+		combinations = append(combinations, append([]int{}, picked...))
+		return false
+	})
+	assert.Equal(t, [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}, combinations)
+
+	count := 0
+	assert.True(t, eachCombination(9, 3, func([]int) bool {
+		count++
+		return count == 5
+	}))
+	assert.Equal(t, 5, count)
+	assert.False(t, eachCombination(2, 3, func([]int) bool { return true }))
+}
@@ -0,0 +1,240 @@
+package soduku
+
+const (
+	// UniqueRectangle1 removes the pair from the fourth corner of a rectangle, over two boxes,
+	// whose other three corners only allow the same two numbers. Otherwise the two numbers
+	// could be swapped, and the puzzle would have two solutions
+	UniqueRectangle1 Technique = "unique rectangle type 1"
+	// UniqueRectangle2 is a rectangle whose two roof corners both allow one extra number. One of
+	// them has to hold it, so it is removed from every square that sees both
+	UniqueRectangle2 Technique = "unique rectangle type 2"
+	// UniqueRectangle3 treats the extra numbers of the roof corners as a single square, which
+	// forms a naked subset with other squares of a house the roof shares
+	UniqueRectangle3 Technique = "unique rectangle type 3"
+	// UniqueRectangle4 is a rectangle where one of the pair can only go in the roof corners of a
+	// house. The roof then cannot hold the other number of the pair
+	UniqueRectangle4 Technique = "unique rectangle type 4"
+	// BUGPlusOne places the extra number of the only square allowing three numbers, when every
+	// other square allows two. Without it the grid would have two solutions
+	BUGPlusOne Technique = "bug+1"
+)
+
+// uniqueRectangles maps the type of a unique rectangle to its technique
+var uniqueRectangles = map[int]Technique{
+	1: UniqueRectangle1,
+	2: UniqueRectangle2,
+	3: UniqueRectangle3,
+	4: UniqueRectangle4,
+}
+
+// findUniqueRectangle returns a technique finding the first unique rectangle of the type,
+// trying rectangles from the top left
+func findUniqueRectangle(typ int) func(b *CandidateBoard) *Step {
+	return func(b *CandidateBoard) *Step {
+		n := b.geo.size
+		for r1 := 0; r1 < n; r1++ {
+			for r2 := r1 + 1; r2 < n; r2++ {
+				for c1 := 0; c1 < n; c1++ {
+					for c2 := c1 + 1; c2 < n; c2++ {
+						corners := []position{
+							{rowNumber: r1, colNumber: c1},
+							{rowNumber: r1, colNumber: c2},
+							{rowNumber: r2, colNumber: c1},
+							{rowNumber: r2, colNumber: c2},
+						}
+						if !swappable(b.geo, corners) {
+							continue
+						}
+						if step := uniqueRectangle(b, corners, typ); step != nil {
+							return step
+						}
+					}
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// uniqueRectangle looks for each pair of numbers allowed by all four corners. The floor is the
+// corners that only allow the pair, the roof is the rest. It returns nil if the rectangle
+// would not remove anything with the type
+func uniqueRectangle(b *CandidateBoard, corners []position, typ int) *Step {
+	common := b.geo.digits
+	for _, pos := range corners {
+		common &= b.candidates(pos)
+	}
+	nums := common.numbers()
+	for i, x := range nums {
+		for _, y := range nums[i+1:] {
+			pair := digitSet(1<<uint(x) | 1<<uint(y))
+			floor, roof := []position{}, []position{}
+			for _, pos := range corners {
+				if b.candidates(pos) == pair {
+					floor = append(floor, pos)
+				} else {
+					roof = append(roof, pos)
+				}
+			}
+
+			var elims []Candidate
+			switch {
+			case typ == 1 && len(floor) == 3:
+				elims = candidatesOf(roof[0], pair)
+			case len(floor) != 2 || !sameLine(roof[0], roof[1]):
+				continue
+			case typ == 2:
+				elims = urType2(b, pair, roof)
+			case typ == 3:
+				elims = urType3(b, pair, roof)
+			case typ == 4:
+				elims = urType4(b, pair, roof)
+			}
+			if len(elims) > 0 {
+				return &Step{
+					Technique:    uniqueRectangles[typ],
+					Eliminations: elims,
+					Reasons:      cellsOf(corners),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// urType2 removes the roof's single extra number from squares seeing both roof corners
+func urType2(b *CandidateBoard, pair digitSet, roof []position) []Candidate {
+	extra := b.candidates(roof[0]) &^ pair
+	if extra.count() != 1 || b.candidates(roof[1])&^pair != extra {
+		return nil
+	}
+	return seenByAll(b, getEmptySquares(b.grid), extra.numbers()[0], roof[0], roof[1])
+}
+
+// urType3 looks for squares in a house holding both roof corners that, with the roof's extra
+// numbers standing in for one square, form a naked subset
+func urType3(b *CandidateBoard, pair digitSet, roof []position) []Candidate {
+	extras := (b.candidates(roof[0]) | b.candidates(roof[1])) &^ pair
+	for _, h := range b.geo.sharedHouses(roof[0], roof[1]) {
+		others := []position{}
+		for _, pos := range emptyPositions(b, h) {
+			if !containsPosition(roof, pos) {
+				others = append(others, pos)
+			}
+		}
+
+		var elims []Candidate
+		for size := 1; size <= 3 && elims == nil; size++ {
+			eachCombination(len(others), size, func(picked []int) bool {
+				union := extras
+				subset := []position{}
+				for _, i := range picked {
+					union |= b.candidates(others[i])
+					subset = append(subset, others[i])
+				}
+				if union.count() != size+1 {
+					return false
+				}
+				found := []Candidate{}
+				for _, pos := range others {
+					if !containsPosition(subset, pos) {
+						found = append(found, candidatesOf(pos, b.candidates(pos)&union)...)
+					}
+				}
+				if len(found) == 0 {
+					return false
+				}
+				elims = found
+				return true
+			})
+		}
+		if elims != nil {
+			return elims
+		}
+	}
+	return nil
+}
+
+// urType4 removes the other number of the pair from the roof, when one number of the pair can
+// only go in the roof corners of a house they share
+func urType4(b *CandidateBoard, pair digitSet, roof []position) []Candidate {
+	for _, h := range b.geo.sharedHouses(roof[0], roof[1]) {
+		for _, num := range pair.numbers() {
+			if len(positionsAllowing(b, h, num)) != 2 {
+				continue
+			}
+			other := pair &^ (1 << uint(num))
+			elims := append(candidatesOf(roof[0], b.candidates(roof[0])&other),
+				candidatesOf(roof[1], b.candidates(roof[1])&other)...)
+			if len(elims) > 0 {
+				return elims
+			}
+		}
+	}
+	return nil
+}
+
+// findBUGPlusOne returns the placement for a grid where every empty square allows two
+// numbers, apart from one square that allows three. The number allowed three times in that
+// square's row has to go there. Cage sums can rule out one of the two solutions of the
+// pattern, so a killer grid is never given a BUG+1
+func findBUGPlusOne(b *CandidateBoard) *Step {
+	if len(b.geo.cages) > 0 {
+		return nil
+	}
+	var extra *position
+	empty := getEmptySquares(b.grid)
+	for i, pos := range empty {
+		switch b.candidates(pos).count() {
+		case 2:
+		case 3:
+			if extra != nil {
+				return nil
+			}
+			extra = &empty[i]
+		default:
+			return nil
+		}
+	}
+	if extra == nil {
+		return nil
+	}
+
+	for _, num := range b.candidates(*extra).numbers() {
+		if len(positionsAllowing(b, b.geo.rowHouse(extra.rowNumber), num)) == 3 {
+			return &Step{
+				Technique: BUGPlusOne,
+				Cell:      Cell{Row: extra.rowNumber, Col: extra.colNumber},
+				Digit:     num,
+			}
+		}
+	}
+	return nil
+}
+
+// sameLine returns whether two squares share a row or a column
+func sameLine(a, b position) bool {
+	return a.rowNumber == b.rowNumber || a.colNumber == b.colNumber
+}
+
+// swappable returns whether the two numbers of a rectangle could be swapped between its
+// corners and leave every house holding the same numbers. Every house has to hold none of the
+// corners, or two that share a row or column, so for boxes the rectangle covers exactly two.
+// Cages are held to the same rule, which also keeps their sums
+func swappable(g *geometry, corners []position) bool {
+	inHouse := map[int][]position{}
+	for _, pos := range corners {
+		for _, h := range g.housesAt[pos.rowNumber][pos.colNumber] {
+			inHouse[h] = append(inHouse[h], pos)
+		}
+		if c := g.cageOf[pos.rowNumber][pos.colNumber]; c >= 0 {
+			inHouse[len(g.houses)+c] = append(inHouse[len(g.houses)+c], pos)
+		}
+	}
+	for _, poss := range inHouse {
+		if len(poss) != 2 || !sameLine(poss[0], poss[1]) {
+			return false
+		}
+	}
+	return true
+}
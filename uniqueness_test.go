@@ -0,0 +1,103 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveGridUniqueness(t *testing.T) {
+	tt := []struct {
+		description     string
+		input           string
+		expectTechnique Technique
+	}{
+		{
+			description:     "unique rectangle type 1",
+			input:           "087900500000040800000620003000039000002400070700000106070000000630005200005080000",
+			expectTechnique: UniqueRectangle1,
+		},
+		{
+			description:     "unique rectangle type 2",
+			input:           "070308690000600035000000200700000000064090020213004000500400001000000003000071900",
+			expectTechnique: UniqueRectangle2,
+		},
+		{
+			description:     "unique rectangle type 3",
+			input:           "000037209000000450000100067400000000709003000060020300080050026000082900500700000",
+			expectTechnique: UniqueRectangle3,
+		},
+		{
+			description:     "unique rectangle type 4",
+			input:           "100270000000013007007000000900000004005100200310005008009500600800004000200690000",
+			expectTechnique: UniqueRectangle4,
+		},
+		{
+			description:     "bug+1",
+			input:           "008700009900010400070000002006000090050163004810400000000900050001005000790200030",
+			expectTechnique: BUGPlusOne,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+
+			log := &SolveLog{}
+			solved, cg, err := SolveGrid(g.Rows(), WithSolveLog(log), WithUniquenessTechniques())
+			require.Nil(t, err)
+			assert.True(t, cg.Unique)
+
+			techniques := replaySolveLog(t, g.Rows(), solved, log)
+			assert.True(t, techniques[td.expectTechnique] > 0)
+			assert.Equal(t, 0, techniques[Search])
+
+			// The technique is not used unless it is asked for
+			log = &SolveLog{}
+			solved, _, err = SolveGrid(g.Rows(), WithSolveLog(log))
+			require.Nil(t, err)
+
+			techniques = replaySolveLog(t, g.Rows(), solved, log)
+			assert.Equal(t, 0, techniques[td.expectTechnique])
+		})
+	}
+}
+
+func TestSolveGridUniquenessNotUnique(t *testing.T) {
+	// Removing the first clue of the type 1 puzzle gives it more than one solution, so the
+	// uniqueness techniques are not used even when asked for
+	g, err := ParseGrid("007900500000040800000620003000039000002400070700000106070000000630005200005080000")
+	require.Nil(t, err)
+
+	log := &SolveLog{}
+	solved, cg, err := SolveGrid(g.Rows(), WithSolveLog(log), WithUniquenessTechniques())
+	require.Nil(t, err)
+	assert.False(t, cg.Unique)
+
+	techniques := replaySolveLog(t, g.Rows(), solved, log)
+	for _, tech := range []Technique{UniqueRectangle1, UniqueRectangle2, UniqueRectangle3, UniqueRectangle4, BUGPlusOne} {
+		assert.Equal(t, 0, techniques[tech])
+	}
+}
+
+func TestUniqueRectangle1(t *testing.T) {
+	// r1c1, r1c4 and r2c1 only allow 1 and 2. If r2c4 held 1 or 2 the pair could be swapped
+	// between the corners, so it has to hold 3
+	b := newCandidateBoard(newGeometry(3, 3), emptyTestGrid())
+	b.cands[0][0] = 1<<1 | 1<<2
+	b.cands[0][3] = 1<<1 | 1<<2
+	b.cands[1][0] = 1<<1 | 1<<2
+	b.cands[1][3] = 1<<1 | 1<<2 | 1<<3
+
+	assert.Equal(t, &Step{
+		Technique: UniqueRectangle1,
+		Eliminations: []Candidate{
+			{Cell: Cell{Row: 1, Col: 3}, Digit: 1},
+			{Cell: Cell{Row: 1, Col: 3}, Digit: 2},
+		},
+		Reasons: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 3}, {Row: 1, Col: 0}, {Row: 1, Col: 3}},
+	}, findUniqueRectangle(1)(b))
+	assert.Nil(t, findUniqueRectangle(2)(b))
+}
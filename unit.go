@@ -0,0 +1,96 @@
+package soduku
+
+import (
+	"fmt"
+)
+
+// Cell is the position of a square in the grid, rows and columns are numbered from 0
+type Cell struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+func (c Cell) String() string {
+	return fmt.Sprintf("r%dc%d", c.Row+1, c.Col+1)
+}
+
+// UnitKind is the type of a group of squares that must hold each number once
+type UnitKind int
+
+const (
+	// UnitRow is a row of the grid
+	UnitRow UnitKind = iota
+	// UnitColumn is a column of the grid
+	UnitColumn
+	// UnitBox is one of the regions the grid is split into, a box or a piece of a jigsaw
+	UnitBox
+	// UnitDiagonal is one of the main diagonals of a Sudoku-X grid, index 0 runs from the top
+	// left corner and index 1 from the top right
+	UnitDiagonal
+	// UnitExtra is one of the extra units given by WithUnits, such as a window of Hyper Sudoku,
+	// indexed in the order given
+	UnitExtra
+)
+
+var unitKindNames = map[UnitKind]string{
+	UnitRow:      "row",
+	UnitColumn:   "column",
+	UnitBox:      "box",
+	UnitDiagonal: "diagonal",
+	UnitExtra:    "extra unit",
+}
+
+func (k UnitKind) String() string {
+	if name, ok := unitKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("UnitKind(%d)", int(k))
+}
+
+// MarshalText writes the kind by name, so it reads well in JSON
+func (k UnitKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Unit is a single row, column, box or diagonal. Rows and columns are indexed from the top left, boxes
+// are indexed in reading order and jigsaw pieces in the order of the layout
+type Unit struct {
+	Kind  UnitKind `json:"kind"`
+	Index int      `json:"index"`
+}
+
+func (u Unit) String() string {
+	return fmt.Sprintf("%s %d", u.Kind, u.Index)
+}
+
+// house is a unit with the positions of its squares, in reading order
+type house struct {
+	Unit
+	positions []position
+}
+
+// buildHouses lists the squares of every row, then every column, then every region of a
+// size x size grid
+func buildHouses(size int, regions []region) []house {
+	houses := []house{}
+	for row := 0; row < size; row++ {
+		h := house{Unit: Unit{Kind: UnitRow, Index: row}}
+		for col := 0; col < size; col++ {
+			h.positions = append(h.positions, position{rowNumber: row, colNumber: col})
+		}
+		houses = append(houses, h)
+	}
+	for col := 0; col < size; col++ {
+		h := house{Unit: Unit{Kind: UnitColumn, Index: col}}
+		for row := 0; row < size; row++ {
+			h.positions = append(h.positions, position{rowNumber: row, colNumber: col})
+		}
+		houses = append(houses, h)
+	}
+	for regNum, reg := range regions {
+		h := house{Unit: Unit{Kind: UnitBox, Index: regNum}}
+		h.positions = append(h.positions, reg...)
+		houses = append(houses, h)
+	}
+	return houses
+}
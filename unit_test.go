@@ -0,0 +1,31 @@
+package soduku
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConflictJSON(t *testing.T) {
+	c := Conflict{
+		Unit:  Unit{Kind: UnitBox, Index: 4},
+		Digit: 1,
+		Cells: []Cell{{Row: 4, Col: 4}, {Row: 5, Col: 5}},
+	}
+	b, err := json.Marshal(c)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"kind": "box",
+		"index": 4,
+		"digit": 1,
+		"cells": [{"row": 4, "col": 4}, {"row": 5, "col": 5}]
+	}`, string(b))
+}
+
+func TestUnitString(t *testing.T) {
+	assert.Equal(t, "row 2", Unit{Kind: UnitRow, Index: 2}.String())
+	assert.Equal(t, "column 0", Unit{Kind: UnitColumn, Index: 0}.String())
+	assert.Equal(t, "r1c9", Cell{Row: 0, Col: 8}.String())
+}
@@ -0,0 +1,76 @@
+package soduku
+
+import (
+	"fmt"
+)
+
+// UnitError is returned when an extra unit given by WithUnits cannot hold every number once
+type UnitError struct {
+	// Index is the position of the unit among the extra units
+	Index int
+	// Cell is the square that is off the grid or repeated, or nil if the unit is the wrong size
+	Cell *Cell
+	// Length is the number of squares in the unit
+	Length int
+	// Size is the number of squares expected
+	Size int
+}
+
+func (e *UnitError) Error() string {
+	if e.Cell != nil {
+		return fmt.Sprintf("unexpected square %s in extra unit %d", e.Cell, e.Index)
+	}
+	return fmt.Sprintf("expected %d squares in extra unit %d, found %d", e.Size, e.Index, e.Length)
+}
+
+// HyperWindows returns the four windows of a 9x9 Hyper Sudoku, or Windoku, to pass to
+// WithUnits. The windows are the 3x3 squares starting at rows and columns 1 and 5, in reading
+// order
+func HyperWindows() [][]Cell {
+	windows := [][]Cell{}
+	for _, top := range []int{1, 5} {
+		for _, left := range []int{1, 5} {
+			window := []Cell{}
+			for row := top; row < top+3; row++ {
+				for col := left; col < left+3; col++ {
+					window = append(window, Cell{Row: row, Col: col})
+				}
+			}
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+// diagonalHouses returns the two main diagonals of a size x size grid, the one from the top
+// left corner first
+func diagonalHouses(size int) []house {
+	down := house{Unit: Unit{Kind: UnitDiagonal, Index: 0}}
+	up := house{Unit: Unit{Kind: UnitDiagonal, Index: 1}}
+	for i := 0; i < size; i++ {
+		down.positions = append(down.positions, position{rowNumber: i, colNumber: i})
+		up.positions = append(up.positions, position{rowNumber: i, colNumber: size - 1 - i})
+	}
+	return []house{down, up}
+}
+
+// extraHouses returns the houses of the extra units of a size x size grid, checking each holds
+// size squares of the grid once
+func extraHouses(size int, units [][]Cell) ([]house, error) {
+	houses := []house{}
+	for i, u := range units {
+		if len(u) != size {
+			return nil, &UnitError{Index: i, Length: len(u), Size: size}
+		}
+		h := house{Unit: Unit{Kind: UnitExtra, Index: i}}
+		for j, c := range u {
+			pos := position{rowNumber: c.Row, colNumber: c.Col}
+			if c.Row < 0 || c.Row >= size || c.Col < 0 || c.Col >= size || containsPosition(h.positions, pos) {
+				return nil, &UnitError{Index: i, Cell: &u[j], Length: len(u), Size: size}
+			}
+			h.positions = append(h.positions, pos)
+		}
+		houses = append(houses, h)
+	}
+	return houses, nil
+}
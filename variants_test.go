@@ -0,0 +1,172 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveGridDiagonals(t *testing.T) {
+	g, err := ParseGrid("000000000000087061000000507000000000000003790000250000081000000500000000040709008")
+	require.Nil(t, err)
+	expect, err := ParseGrid("714536289259487361863192547635978412128643795497251836981325674576814923342769158")
+	require.Nil(t, err)
+
+	// The clues alone do not fix the solution, the diagonals are needed too
+	n, err := CountSolutions(g.Rows(), 2)
+	require.Nil(t, err)
+	assert.Equal(t, 2, n)
+	n, err = CountSolutions(g.Rows(), 2, WithDiagonals())
+	require.Nil(t, err)
+	assert.Equal(t, 1, n)
+
+	log := &SolveLog{}
+	solved, _, err := SolveGrid(g.Rows(), WithDiagonals(), WithSolveLog(log))
+	require.Nil(t, err)
+	assert.Equal(t, expect.Rows(), solved)
+	techniques := replaySolveLog(t, g.Rows(), solved, log)
+	assert.True(t, techniques[PointingCandidates] > 0)
+	assert.Equal(t, 0, techniques[Search])
+
+	solved, _, err = SolveGrid(g.Rows(), WithDiagonals(), WithEngine(EngineDLX))
+	require.Nil(t, err)
+	assert.Equal(t, expect.Rows(), solved)
+}
+
+func TestCheckGridDiagonals(t *testing.T) {
+	// The two 5s only share the diagonal from the top left
+	grid := emptyTestGrid()
+	grid[1][1] = 5
+	grid[3][3] = 5
+	assert.True(t, CheckGrid(grid).Valid)
+
+	cg := CheckGrid(grid, WithDiagonals())
+	assert.False(t, cg.Valid)
+	assert.Equal(t, []Conflict{
+		{Unit: Unit{Kind: UnitDiagonal, Index: 0}, Digit: 5, Cells: []Cell{{Row: 1, Col: 1}, {Row: 3, Col: 3}}},
+	}, cg.Conflicts)
+	assert.Equal(t, " A duplicate of 5 was found in the diagonal from the top left\n", cg.Message)
+
+	// A complete grid can break both diagonals
+	cg = CheckGrid(patternGrid(3, 3), WithDiagonals())
+	assert.False(t, cg.Valid)
+	assert.Len(t, cg.Conflicts, 6)
+	assert.Equal(t, Unit{Kind: UnitDiagonal, Index: 1}, cg.Conflicts[5].Unit)
+}
+
+func TestNewSquareDiagonals(t *testing.T) {
+	grid := emptyTestGrid()
+	grid[8][8] = 5
+	grid[6][2] = 7
+
+	s, err := NewSquare(grid, position{rowNumber: 0, colNumber: 0})
+	require.Nil(t, err)
+	assert.Contains(t, s.possibleNums, 5)
+
+	s, err = NewSquare(grid, position{rowNumber: 0, colNumber: 0}, WithDiagonals())
+	require.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 6, 7, 8, 9}, s.possibleNums)
+
+	// The centre is on both diagonals
+	s, err = NewSquare(grid, position{rowNumber: 4, colNumber: 4}, WithDiagonals())
+	require.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 6, 8, 9}, s.possibleNums)
+}
+
+func TestHyperWindows(t *testing.T) {
+	windows := HyperWindows()
+	require.Len(t, windows, 4)
+	for _, w := range windows {
+		assert.Len(t, w, 9)
+	}
+	assert.Equal(t, Cell{Row: 1, Col: 1}, windows[0][0])
+	assert.Equal(t, Cell{Row: 3, Col: 3}, windows[0][8])
+	assert.Equal(t, Cell{Row: 1, Col: 5}, windows[1][0])
+	assert.Equal(t, Cell{Row: 5, Col: 1}, windows[2][0])
+	assert.Equal(t, Cell{Row: 7, Col: 7}, windows[3][8])
+}
+
+func TestSolveGridHyper(t *testing.T) {
+	g, err := ParseGrid("000000007000000031089000000000005003000100000000000000000003000002470005400080000")
+	require.Nil(t, err)
+	expect, err := ParseGrid("136824597245697831789351264821765943594138726673942158968513472312479685457286319")
+	require.Nil(t, err)
+
+	n, err := CountSolutions(g.Rows(), 2)
+	require.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	log := &SolveLog{}
+	solved, _, err := SolveGrid(g.Rows(), WithUnits(HyperWindows()...), WithSolveLog(log))
+	require.Nil(t, err)
+	assert.Equal(t, expect.Rows(), solved)
+	techniques := replaySolveLog(t, g.Rows(), solved, log)
+	assert.True(t, techniques[PointingCandidates] > 0)
+	assert.Equal(t, 0, techniques[Search])
+
+	solved, _, err = SolveGrid(g.Rows(), WithUnits(HyperWindows()...), WithEngine(EngineDLX))
+	require.Nil(t, err)
+	assert.Equal(t, expect.Rows(), solved)
+}
+
+func TestCheckGridExtraUnits(t *testing.T) {
+	// The two 5s only share the first window
+	grid := emptyTestGrid()
+	grid[1][1] = 5
+	grid[3][3] = 5
+	assert.True(t, CheckGrid(grid).Valid)
+
+	cg := CheckGrid(grid, WithUnits(HyperWindows()...))
+	assert.False(t, cg.Valid)
+	assert.Equal(t, []Conflict{
+		{Unit: Unit{Kind: UnitExtra, Index: 0}, Digit: 5, Cells: []Cell{{Row: 1, Col: 1}, {Row: 3, Col: 3}}},
+	}, cg.Conflicts)
+	assert.Equal(t, " A duplicate of 5 was found in extra unit 0\n", cg.Message)
+
+	tt := []struct {
+		description string
+		units       [][]Cell
+		expectErr   error
+	}{
+		{
+			description: "too few squares",
+			units:       [][]Cell{HyperWindows()[0][:8]},
+			expectErr:   &UnitError{Index: 0, Length: 8, Size: 9},
+		},
+		{
+			description: "square off the grid",
+			units:       [][]Cell{HyperWindows()[0], append(HyperWindows()[1][:8], Cell{Row: 9, Col: 0})},
+			expectErr:   &UnitError{Index: 1, Cell: &Cell{Row: 9, Col: 0}, Length: 9, Size: 9},
+		},
+		{
+			description: "repeated square",
+			units:       [][]Cell{append(HyperWindows()[0][:8], Cell{Row: 1, Col: 1})},
+			expectErr:   &UnitError{Index: 0, Cell: &Cell{Row: 1, Col: 1}, Length: 9, Size: 9},
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			cg := CheckGrid(emptyTestGrid(), WithUnits(td.units...))
+			assert.False(t, cg.Valid)
+			assert.Equal(t, td.expectErr.Error(), cg.Message)
+
+			_, _, err := SolveGrid(emptyTestGrid(), WithUnits(td.units...))
+			assert.Equal(t, td.expectErr, err)
+		})
+	}
+}
+
+func TestNewSquareExtraUnits(t *testing.T) {
+	grid := emptyTestGrid()
+	grid[3][3] = 5
+
+	s, err := NewSquare(grid, position{rowNumber: 1, colNumber: 1})
+	require.Nil(t, err)
+	assert.Contains(t, s.possibleNums, 5)
+
+	s, err = NewSquare(grid, position{rowNumber: 1, colNumber: 1}, WithUnits(HyperWindows()...))
+	require.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 6, 7, 8, 9}, s.possibleNums)
+}
@@ -0,0 +1,155 @@
+package soduku
+
+const (
+	// XYWing uses a pivot square allowing only x and y, which sees a pincer allowing only x and
+	// z and another allowing only y and z. Whichever the pivot holds, one pincer holds z, so z
+	// is removed from every square that sees both pincers
+	XYWing Technique = "xy-wing"
+	// XYZWing is an xy-wing where the pivot also allows z, so z is only removed from squares
+	// that see the pivot as well as both pincers
+	XYZWing Technique = "xyz-wing"
+	// WWing uses two squares that both allow only x and y, joined by a house where x can only
+	// go in a square seeing one or the other. One of the pincers has to hold y, so y is
+	// removed from every square that sees both
+	WWing Technique = "w-wing"
+)
+
+// findXYWing returns the first xy-wing that removes a candidate, trying pivots in reading
+// order
+func findXYWing(b *CandidateBoard) *Step {
+	empty := getEmptySquares(b.grid)
+	for _, pivot := range empty {
+		pc := b.candidates(pivot)
+		if pc.count() != 2 {
+			continue
+		}
+		for _, x := range bivalueSeen(b, empty, pivot) {
+			xc := b.candidates(x)
+			if (xc & pc).count() != 1 {
+				continue
+			}
+			// The other pincer holds the pivot's remaining number and the z of the first
+			z := xc &^ pc
+			want := (pc &^ xc) | z
+			for _, y := range bivalueSeen(b, empty, pivot) {
+				if b.candidates(y) != want {
+					continue
+				}
+				num := z.numbers()[0]
+				if elims := seenByAll(b, empty, num, x, y); len(elims) > 0 {
+					return wingStep(XYWing, &pivot, []position{x, y}, elims)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findXYZWing returns the first xyz-wing that removes a candidate, trying pivots in reading
+// order
+func findXYZWing(b *CandidateBoard) *Step {
+	empty := getEmptySquares(b.grid)
+	for _, pivot := range empty {
+		pc := b.candidates(pivot)
+		if pc.count() != 3 {
+			continue
+		}
+		pincers := []position{}
+		for _, pos := range bivalueSeen(b, empty, pivot) {
+			if b.candidates(pos)&^pc == 0 {
+				pincers = append(pincers, pos)
+			}
+		}
+		for i, x := range pincers {
+			for _, y := range pincers[i+1:] {
+				xc, yc := b.candidates(x), b.candidates(y)
+				if (xc|yc) != pc || (xc&yc).count() != 1 {
+					continue
+				}
+				num := (xc & yc).numbers()[0]
+				if elims := seenByAll(b, empty, num, pivot, x, y); len(elims) > 0 {
+					return wingStep(XYZWing, &pivot, []position{x, y}, elims)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findWWing returns the first w-wing that removes a candidate. The squares of the house that
+// link the pincers are given as the reasons
+func findWWing(b *CandidateBoard) *Step {
+	empty := getEmptySquares(b.grid)
+	for i, x := range empty {
+		xc := b.candidates(x)
+		if xc.count() != 2 {
+			continue
+		}
+		for _, y := range empty[i+1:] {
+			if b.candidates(y) != xc || b.geo.sees(x, y) {
+				continue
+			}
+			nums := xc.numbers()
+			for j, link := range nums {
+				other := nums[1-j]
+				elims := seenByAll(b, empty, other, x, y)
+				if len(elims) == 0 {
+					continue
+				}
+				for _, h := range b.geo.houses {
+					poss := positionsAllowing(b, h, link)
+					if len(poss) != 2 || containsPosition(poss, x) || containsPosition(poss, y) {
+						continue
+					}
+					if (b.geo.sees(poss[0], x) && b.geo.sees(poss[1], y)) || (b.geo.sees(poss[0], y) && b.geo.sees(poss[1], x)) {
+						step := wingStep(WWing, nil, []position{x, y}, elims)
+						step.Reasons = cellsOf(poss)
+						return step
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// wingStep builds the step for a wing, the pivot is nil for wings that do not have one
+func wingStep(t Technique, pivot *position, pincers []position, elims []Candidate) *Step {
+	step := &Step{Technique: t, Eliminations: elims, Pincers: cellsOf(pincers)}
+	if pivot != nil {
+		step.Pivot = &Cell{Row: pivot.rowNumber, Col: pivot.colNumber}
+	}
+	return step
+}
+
+// bivalueSeen returns the empty squares that see pos and allow exactly two numbers
+func bivalueSeen(b *CandidateBoard, empty []position, pos position) []position {
+	seen := []position{}
+	for _, p := range empty {
+		if b.candidates(p).count() == 2 && b.geo.sees(pos, p) {
+			seen = append(seen, p)
+		}
+	}
+	return seen
+}
+
+// seenByAll returns a candidate for num in every empty square that sees all of the positions
+func seenByAll(b *CandidateBoard, empty []position, num int, poss ...position) []Candidate {
+	elims := []Candidate{}
+	for _, pos := range empty {
+		if !b.candidates(pos).has(num) {
+			continue
+		}
+		all := true
+		for _, p := range poss {
+			if !b.geo.sees(pos, p) {
+				all = false
+				break
+			}
+		}
+		if all {
+			elims = append(elims, candidatesOf(pos, 1<<uint(num))...)
+		}
+	}
+	return elims
+}
@@ -0,0 +1,92 @@
+package soduku
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveGridWings(t *testing.T) {
+	tt := []struct {
+		description     string
+		input           string
+		expectTechnique Technique
+		expectPivot     bool
+	}{
+		{
+			description:     "xy-wing",
+			input:           "000000604400028970005000021950000000004200009807004000009003100010080007000002008",
+			expectTechnique: XYWing,
+			expectPivot:     true,
+		},
+		{
+			description:     "xyz-wing",
+			input:           "004000350002390007010506000000020900030057000401600000740000200500040060000009000",
+			expectTechnique: XYZWing,
+			expectPivot:     true,
+		},
+		{
+			description:     "w-wing",
+			input:           "000000003010007005290000006001000800600005000780400001570300000008500040002008100",
+			expectTechnique: WWing,
+		},
+	}
+
+	for _, td := range tt {
+		t.Run(td.description, func(t *testing.T) {
+			g, err := ParseGrid(td.input)
+			require.Nil(t, err)
+
+			log := &SolveLog{}
+			solved, _, err := SolveGrid(g.Rows(), WithSolveLog(log))
+			require.Nil(t, err)
+
+			techniques := replaySolveLog(t, g.Rows(), solved, log)
+			assert.True(t, techniques[td.expectTechnique] > 0)
+			assert.Equal(t, 0, techniques[Search])
+
+			for _, s := range log.Steps {
+				if s.Technique != td.expectTechnique {
+					continue
+				}
+				assert.Len(t, s.Pincers, 2)
+				assert.Equal(t, td.expectPivot, s.Pivot != nil)
+				assert.Contains(t, s.String(), "pincers ")
+			}
+
+			// Turning the wing off leaves it out of the log, the puzzle is still solved by the
+			// chains or by searching
+			log = &SolveLog{}
+			solved, _, err = SolveGrid(g.Rows(), WithSolveLog(log), WithoutTechniques(td.expectTechnique))
+			require.Nil(t, err)
+
+			techniques = replaySolveLog(t, g.Rows(), solved, log)
+			assert.Equal(t, 0, techniques[td.expectTechnique])
+		})
+	}
+}
+
+func TestXYWing(t *testing.T) {
+	// The pivot r1c1 allows 1 and 2, r1c5 allows 1 and 3 and r5c1 allows 2 and 3. Either
+	// pincer holds 3, so r5c5 which sees both cannot
+	b := newCandidateBoard(newGeometry(3, 3), emptyTestGrid())
+	keep := map[Cell]digitSet{
+		{Row: 0, Col: 0}: 1<<1 | 1<<2,
+		{Row: 0, Col: 4}: 1<<1 | 1<<3,
+		{Row: 4, Col: 0}: 1<<2 | 1<<3,
+	}
+	for c, d := range keep {
+		b.cands[c.Row][c.Col] = d
+	}
+
+	step := findXYWing(b)
+	require.NotNil(t, step)
+	assert.Equal(t, &Step{
+		Technique:    XYWing,
+		Eliminations: []Candidate{{Cell: Cell{Row: 4, Col: 4}, Digit: 3}},
+		Pivot:        &Cell{Row: 0, Col: 0},
+		Pincers:      []Cell{{Row: 0, Col: 4}, {Row: 4, Col: 0}},
+	}, step)
+	assert.Equal(t, "xy-wing: r5c5<>3 [pivot r1c1, pincers r1c5, r5c1]", step.String())
+}